@@ -0,0 +1,124 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+// decides whether values of a type are comparable (and therefore usable as map keys)
+// following https://golang.org/ref/spec#Comparison_operators
+type comparabilityChecker struct {
+	yamlData map[interface{}]interface{}
+	// names of the declared types currently being checked
+	visiting map[string]bool
+}
+
+func newComparabilityChecker(yamlData map[interface{}]interface{}) *comparabilityChecker {
+	return &comparabilityChecker{yamlData: yamlData, visiting: make(map[string]bool)}
+}
+
+func isComparable(typeExpr ast.Expr, yamlData map[interface{}]interface{}) bool {
+	return newComparabilityChecker(yamlData).isComparableExpr(typeExpr)
+}
+
+func (c *comparabilityChecker) isComparableExpr(typeExpr ast.Expr) bool {
+	switch e := typeExpr.(type) {
+	case *ast.Ident:
+		return c.isComparableTypeName(e.Name)
+	// types of external packages are as comparable as declared
+	case *ast.SelectorExpr:
+		if qualifiedName, ok := typeNameOf(e); ok {
+			if isComparable, ok := externalTypeOf(c.yamlData, qualifiedName); ok {
+				return isComparable
+			}
+		}
+		return true
+	case *ast.ParenExpr:
+		return c.isComparableExpr(e.X)
+	// instantiations are as comparable as their generic type; type parameters are
+	// undeclared names and therefore assumed to be comparable, the type check catches
+	// type arguments making an instantiation incomparable
+	case *ast.IndexExpr:
+		return c.isComparableExpr(e.X)
+	case *ast.IndexListExpr:
+		return c.isComparableExpr(e.X)
+	// pointers, channels and interfaces are compared by identity
+	case *ast.StarExpr, *ast.ChanType, *ast.InterfaceType:
+		return true
+	case *ast.MapType, *ast.FuncType:
+		return false
+	case *ast.ArrayType:
+		// slices are not comparable, arrays are if their elements are
+		if e.Len == nil {
+			return false
+		}
+		return c.isComparableExpr(e.Elt)
+	case *ast.StructType:
+		for _, field := range e.Fields.List {
+			if !c.isComparableExpr(field.Type) {
+				return false
+			}
+		}
+		return true
+	}
+	// undeclared types are reported as ErrTypeNotFound
+	return true
+}
+
+func (c *comparabilityChecker) isComparableTypeName(typeName string) bool {
+	value, ok := declarationOf(c.yamlData, typeName)
+	// basic types are comparable; undeclared types are reported as ErrTypeNotFound;
+	// recursive types are reported as ErrRecursiveTypeUsage
+	if !ok || c.visiting[typeName] {
+		return true
+	}
+
+	// interface values are compared by their dynamic types and values
+	if isInterface(value) {
+		return true
+	}
+
+	c.visiting[typeName] = true
+	defer delete(c.visiting, typeName)
+
+	if isString(value) {
+		return c.isComparableValueString(fmt.Sprintf("%v", value))
+	}
+
+	if isMap(value) {
+		return c.isComparableObject(value.(map[interface{}]interface{}))
+	}
+
+	return true
+}
+
+// a struct is comparable if all its fields are, including the ones of nested objects
+func (c *comparabilityChecker) isComparableObject(yamlObjectData map[interface{}]interface{}) bool {
+	isComparableStruct := true
+	rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+		// the key of an embedded field is the type it embeds
+		if isEmbeddedField(value) {
+			value = keyName
+		}
+
+		if isMap(value) {
+			if !c.isComparableObject(value.(map[interface{}]interface{})) {
+				isComparableStruct = false
+			}
+			return
+		}
+		if !c.isComparableValueString(fmt.Sprintf("%v", value)) {
+			isComparableStruct = false
+		}
+	})
+	return isComparableStruct
+}
+
+func (c *comparabilityChecker) isComparableValueString(valueString string) bool {
+	typeExpr, err := parseTypeExpr(valueString)
+	// invalid value strings are reported as ErrInvalidValueString
+	if err != nil {
+		return true
+	}
+	return c.isComparableExpr(typeExpr)
+}
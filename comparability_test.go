@@ -0,0 +1,151 @@
+package yamltostruct
+
+import (
+	"go/parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsComparable(t *testing.T) {
+	// declarations the test cases refer to
+	data := map[interface{}]interface{}{
+		"sliceType":   "[]int",
+		"mapType":     "map[int]int",
+		"funcType":    "func()",
+		"namedInt":    "int",
+		"namedSlice":  "sliceType",
+		"chanType":    "chan int",
+		"pointerType": "*sliceType",
+		"array":       "[2]sliceType",
+		"comparableStruct": map[interface{}]interface{}{
+			"a": "int",
+			"b": "*sliceType",
+			"c": "[3]string",
+		},
+		"structWithSlice": map[interface{}]interface{}{
+			"a": "int",
+			"b": "[]int",
+		},
+		"structWithMap": map[interface{}]interface{}{
+			"a": "map[string]int",
+		},
+		"structWithFunc": map[interface{}]interface{}{
+			"a": "func() int",
+		},
+		"nestedStruct": map[interface{}]interface{}{
+			"a": "structWithSlice",
+		},
+		"nestedObject": map[interface{}]interface{}{
+			"a": map[interface{}]interface{}{
+				"b": "int",
+			},
+		},
+		"nestedObjectWithSlice": map[interface{}]interface{}{
+			"a": map[interface{}]interface{}{
+				"b": "[]int",
+			},
+		},
+		"embedsStructWithSlice": map[interface{}]interface{}{
+			"structWithSlice": nil,
+		},
+		"embedsPointer": map[interface{}]interface{}{
+			"*structWithSlice": nil,
+		},
+		"iface": interfaceDeclaration{
+			"Get": "func() []int",
+		},
+		"structWithInterface": map[interface{}]interface{}{
+			"a": "iface",
+		},
+		"recursive": map[interface{}]interface{}{
+			"a": "recursive",
+		},
+		"sliceAlias": aliasDeclaration("sliceType"),
+		"intAlias":   aliasDeclaration("int"),
+		genericTypeName{"box", "[T any]"}: map[interface{}]interface{}{
+			"value": "T",
+		},
+		genericTypeName{"bag", "[T any]"}: map[interface{}]interface{}{
+			"values": "[]T",
+		},
+	}
+
+	// https://golang.org/ref/spec#Comparison_operators
+	testCases := []struct {
+		typeExpr     string
+		isComparable bool
+	}{
+		// boolean, integer, floating-point, complex and string values are comparable
+		{"bool", true},
+		{"int", true},
+		{"float64", true},
+		{"complex128", true},
+		{"string", true},
+		{"namedInt", true},
+		// aliases are comparable if the aliased types are
+		{"intAlias", true},
+		{"sliceAlias", false},
+		// pointer values are comparable
+		{"*int", true},
+		{"*sliceType", true},
+		{"*[]int", true},
+		{"pointerType", true},
+		// channel values are comparable
+		{"chan int", true},
+		{"chan<- []int", true},
+		{"<-chan map[int]int", true},
+		{"chanType", true},
+		// interface values are comparable
+		{"interface{}", true},
+		{"interface{ foo() []int }", true},
+		{"error", true},
+		{"iface", true},
+		{"[2]iface", true},
+		{"structWithInterface", true},
+		// struct values are comparable if all their fields are
+		{"struct{}", true},
+		{"struct{ a int; b *int }", true},
+		{"struct{ a []int }", false},
+		{"struct{ a map[int]int }", false},
+		{"struct{ a func() }", false},
+		{"comparableStruct", true},
+		{"structWithSlice", false},
+		{"structWithMap", false},
+		{"structWithFunc", false},
+		{"nestedStruct", false},
+		{"nestedObject", true},
+		{"nestedObjectWithSlice", false},
+		{"embedsStructWithSlice", false},
+		{"embedsPointer", true},
+		// array values are comparable if values of the element type are
+		{"[2]int", true},
+		{"[2]comparableStruct", true},
+		{"[2]structWithSlice", false},
+		{"[2][3]int", true},
+		{"[2][]int", false},
+		{"array", false},
+		// slice, map, and function values are not comparable
+		{"[]int", false},
+		{"map[int]int", false},
+		{"func()", false},
+		{"func(int) string", false},
+		{"sliceType", false},
+		{"namedSlice", false},
+		{"mapType", false},
+		{"funcType", false},
+		// invalid recursive types are reported by other validators
+		{"recursive", true},
+		{"(sliceType)", false},
+		// instantiations are comparable if their generic types are
+		{"box[int]", true},
+		{"bag[int]", false},
+		{"map[string]box[int]", false},
+	}
+
+	for _, testCase := range testCases {
+		typeExpr, err := parser.ParseExpr(testCase.typeExpr)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.isComparable, isComparable(typeExpr, data), testCase.typeExpr)
+	}
+}
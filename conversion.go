@@ -6,73 +6,502 @@ import (
 	"go/parser"
 	"go/token"
 	"sort"
+	"strings"
 )
 
 // used to process map in determined order based on key names
 func rangeInAlphabeticalOrder(data map[interface{}]interface{}, fn func(key string, value interface{})) {
 	var keys []string
+	// keys are not necessarily strings (e.g. "1: int")
+	originalKeys := make(map[string]interface{})
 	for key := range data {
 		keyLiteral := fmt.Sprintf("%v", key)
 		keys = append(keys, keyLiteral)
+		originalKeys[keyLiteral] = key
 	}
 
 	sort.Strings(keys)
 
 	for _, key := range keys {
-		fn(key, data[key])
+		fn(key, data[originalKeys[key]])
 	}
 }
 
-func convertToAST(yamlData map[interface{}]interface{}) *ast.File {
-	sw := newSourceWriter()
+// calls fn for every object declared in yamlData, including nested objects, in alphabetical order;
+// objectName is the path of the object ("foo" for root level objects, "foo.bar" for nested ones)
+func rangeObjects(yamlData map[interface{}]interface{}, fn func(objectName string, yamlObjectData map[interface{}]interface{})) {
+	var rangeNested func(yamlObjectData map[interface{}]interface{}, objectName string)
+	rangeNested = func(yamlObjectData map[interface{}]interface{}, objectName string) {
+		fn(objectName, yamlObjectData)
+		rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+			if isMap(value) {
+				rangeNested(value.(map[interface{}]interface{}), declarationPathOf(keyName, objectName))
+			}
+		})
+	}
 
 	rangeInAlphabeticalOrder(yamlData, func(keyName string, value interface{}) {
+		if isMap(value) {
+			rangeNested(value.(map[interface{}]interface{}), keyName)
+		}
+	})
+}
+
+// calls fn for every type expression declared in yamlData: the values of named types, fields,
+// methods and the types of constants and variables; parentItemName is the path of the object
+// ("root" for named types) or the section the key is declared in
+func rangeTypeExpressions(yamlData map[interface{}]interface{}, fn func(valueString, keyName, parentItemName string)) {
+	var rangeObject func(yamlObjectData map[interface{}]interface{}, objectName string)
+	rangeObject = func(yamlObjectData map[interface{}]interface{}, objectName string) {
+		rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+			if isMap(value) {
+				rangeObject(value.(map[interface{}]interface{}), declarationPathOf(keyName, objectName))
+				return
+			}
+			if isString(value) {
+				fn(fmt.Sprintf("%v", value), keyName, objectName)
+			}
+		})
+	}
+
+	rangeTypeDeclarations(yamlData, func(keyName string, value interface{}) {
+		switch {
+		case isString(value):
+			fn(fmt.Sprintf("%v", value), keyName, "root")
+		case isMap(value):
+			rangeObject(value.(map[interface{}]interface{}), keyName)
+		case isInterface(value):
+			rangeObject(map[interface{}]interface{}(value.(interfaceDeclaration)), keyName)
+		}
+	})
+
+	rangeValueSections(yamlData, func(sectionKey string, section valueSection) {
+		rangeInAlphabeticalOrder(section, func(keyName string, value interface{}) {
+			if typeString, _, ok := valueDeclarationOf(sectionKey, value); ok && typeString != "" {
+				fn(typeString, keyName, sectionKey)
+			}
+		})
+	})
+}
+
+// the comments of the declarations are taken from comments, which may be nil;
+// the declarations are parsed into fileSet so go/printer can place the comments
+func convertToAST(
+	yamlData map[interface{}]interface{},
+	tagger *structTagger,
+	comments declarationPositions,
+	order *declarationOrder,
+	fileSet *token.FileSet,
+) *ast.File {
+	return writeSourceCode(yamlData, tagger, comments, order, true).parse(fileSet)
+}
+
+// writes the declarations as go source code; names which are no identifiers are
+// left out as they would make the whole source code unparsable (in exhaustive mode
+// declarations with illegal names are still present during later validation phases);
+// fields are tagged by the tagger, which may be nil, just like comments and order (which then
+// is alphabetical); the methods of enums are only written with withEnumMethods as they
+// import a package which go/types cannot load
+func writeSourceCode(
+	yamlData map[interface{}]interface{},
+	tagger *structTagger,
+	comments declarationPositions,
+	order *declarationOrder,
+	withEnumMethods bool,
+) *sourceWriter {
+	sw := newSourceWriter(comments)
+
+	// only the packages which are used are imported; the enum methods use fmt
+	imports := make(map[string]string)
+	for _, externalPkg := range usedExternalPackages(yamlData) {
+		imports[externalPkg.name] = externalPkg.path
+	}
+	if withEnumMethods && containsEnums(yamlData) {
+		imports[enumImportName] = enumImportName
+	}
+	var importNames []string
+	for name := range imports {
+		importNames = append(importNames, name)
+	}
+	// ordered by path, just like gofmt orders imports
+	sort.Slice(importNames, func(i, j int) bool {
+		if imports[importNames[i]] != imports[importNames[j]] {
+			return imports[importNames[i]] < imports[importNames[j]]
+		}
+		return importNames[i] < importNames[j]
+	})
+	switch {
+	case len(importNames) == 1:
+		sw.addImport(importNames[0], imports[importNames[0]])
+	case len(importNames) > 1:
+		sw.startDeclarationBlock("import")
+		for _, name := range importNames {
+			sw.addImportSpec(name, imports[name])
+		}
+		sw.closeDeclarationBlock()
+	}
+
+	// constants and variables are declared before the types
+	rangeValueSections(yamlData, func(sectionKey string, section valueSection) {
+		writeValueSection(sw, section, sectionKey, order)
+	})
+
+	order.rangeKeys(yamlData, "root", func(keyName string, value interface{}) {
+		if !token.IsIdentifier(keyName) {
+			return
+		}
+
+		sw.addDocComment(keyName)
+
+		if isEnum(value) {
+			writeEnum(sw, value.([]interface{}), keyName, withEnumMethods)
+			return
+		}
+
+		// generic types are declared with their type parameters ("list[T any]")
+		typeSpecName := typeSpecNameOf(yamlData, keyName)
+
+		if isAlias(value) {
+			sw.addAliasType(typeSpecName, fmt.Sprintf("%v", value))
+			sw.setOrigin(keyName, "root")
+			sw.addLineComment(keyName)
+			return
+		}
+
 		if isString(value) {
 			valueString := fmt.Sprintf("%v", value)
-			sw.addNamedType(keyName, valueString)
+			sw.addNamedType(typeSpecName, valueString)
+			sw.setOrigin(keyName, "root")
+			sw.addLineComment(keyName)
 			return
 		}
 
 		if isMap(value) {
 			mapValue := value.(map[interface{}]interface{})
-			sw.startStructType(keyName)
-			rangeInAlphabeticalOrder(mapValue, func(_key string, _value interface{}) {
-				_valueString := fmt.Sprintf("%v", _value)
-				_keyName := fmt.Sprintf("%v", _key)
-				sw.addStructField(_keyName, _valueString)
-			})
+			sw.startStructType(typeSpecName)
+			sw.setOrigin(keyName, "root")
+			writeStructFields(sw, mapValue, keyName, tagger, order)
 			sw.closeStructType()
+			sw.addLineComment(keyName)
+		}
+
+		if isInterface(value) {
+			sw.startInterfaceType(typeSpecName)
+			sw.setOrigin(keyName, "root")
+			writeInterfaceMethods(sw, value.(interfaceDeclaration), keyName, order)
+			sw.closeInterfaceType()
+			sw.addLineComment(keyName)
+		}
+	})
+
+	return sw
+}
+
+func containsEnums(yamlData map[interface{}]interface{}) bool {
+	for _, value := range yamlData {
+		if isEnum(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// enums are declared as int types with a constant for each value
+func writeEnum(sw *sourceWriter, yamlEnumData []interface{}, enumName string, withEnumMethods bool) {
+	sw.addNamedType(enumName, "int")
+	sw.setOrigin(enumName, "root")
+	sw.addLineComment(enumName)
+
+	values := enumValuesOf(yamlEnumData)
+	withIota := usesIota(values)
+
+	sw.startDeclarationBlock(constSectionKey)
+	isFirstConstant := true
+	for _, value := range values {
+		if !token.IsIdentifier(value.name) {
+			continue
+		}
+		constantName := enumConstantName(enumName, value.name)
+		sw.addDocComment(declarationPathOf(value.name, enumName))
+		switch {
+		case !withIota:
+			sw.addValueSpec(constantName, enumName, fmt.Sprintf("%d", value.value))
+		case isFirstConstant:
+			sw.addValueSpec(constantName, enumName, "iota")
+		default:
+			sw.addValueSpec(constantName, "", "")
+		}
+		sw.setOrigin(value.name, enumName)
+		sw.addLineComment(declarationPathOf(value.name, enumName))
+		isFirstConstant = false
+	}
+	sw.closeDeclarationBlock()
+
+	if withEnumMethods {
+		sw.addSource(enumMethodsSource(enumName, values))
+	}
+}
+
+// the constants or variables of a section are declared in a single block ("const ( ... )")
+func writeValueSection(sw *sourceWriter, section valueSection, sectionKey string, order *declarationOrder) {
+	if len(section) == 0 {
+		return
+	}
+
+	sw.addDocComment(sectionKey)
+	sw.startDeclarationBlock(sectionKey)
+	order.rangeKeys(section, sectionKey, func(keyName string, value interface{}) {
+		typeString, valueString, ok := valueDeclarationOf(sectionKey, value)
+		if !ok || !token.IsIdentifier(keyName) {
+			return
+		}
+		sw.addDocComment(declarationPathOf(keyName, sectionKey))
+		sw.addValueSpec(keyName, typeString, valueString)
+		sw.setOrigin(keyName, sectionKey)
+		sw.addLineComment(declarationPathOf(keyName, sectionKey))
+	})
+	sw.closeDeclarationBlock()
+}
+
+func writeInterfaceMethods(sw *sourceWriter, yamlInterfaceData interfaceDeclaration, interfaceName string, order *declarationOrder) {
+	order.rangeKeys(yamlInterfaceData, interfaceName, func(keyName string, value interface{}) {
+		if !token.IsIdentifier(keyName) {
+			return
+		}
+
+		methodPath := declarationPathOf(keyName, interfaceName)
+
+		if isEmbeddedField(value) {
+			sw.addDocComment(methodPath)
+			sw.addEmbeddedField(keyName)
+			sw.setOrigin(keyName, interfaceName)
+			sw.addLineComment(methodPath)
+			return
+		}
+
+		// "func(p []byte) (int, error)" => "(p []byte) (int, error)"
+		valueString := fmt.Sprintf("%v", value)
+		typeExpr, err := parseTypeExpr(valueString)
+		if err != nil {
+			return
+		}
+		funcType, ok := typeExpr.(*ast.FuncType)
+		if !ok {
+			return
+		}
+		signature := exprSource(valueString, funcType.Params)
+		if funcType.Results != nil {
+			signature += " " + exprSource(valueString, funcType.Results)
+		}
+
+		sw.addDocComment(methodPath)
+		sw.addMethod(keyName, signature)
+		sw.setOrigin(keyName, interfaceName)
+		sw.addLineComment(methodPath)
+	})
+}
+
+func writeStructFields(
+	sw *sourceWriter,
+	yamlObjectData map[interface{}]interface{},
+	objectName string,
+	tagger *structTagger,
+	order *declarationOrder,
+) {
+	order.rangeKeys(yamlObjectData, objectName, func(keyName string, value interface{}) {
+		fieldPath := declarationPathOf(keyName, objectName)
+
+		// embedded fields are not tagged so their fields are promoted when encoded
+		if isEmbeddedField(value) {
+			if token.IsIdentifier(embeddedTypeName(keyName)) {
+				sw.addDocComment(fieldPath)
+				sw.addEmbeddedField(keyName)
+				sw.setOrigin(keyName, objectName)
+				sw.addLineComment(fieldPath)
+			}
+			return
+		}
+
+		if !token.IsIdentifier(keyName) {
+			return
+		}
+
+		tag := tagger.tagOf(fieldPath, keyName, isOptionalField(value))
+		sw.addDocComment(fieldPath)
+
+		// nested objects become anonymous struct types
+		if isMap(value) {
+			sw.startStructField(keyName)
+			sw.setOrigin(keyName, objectName)
+			writeStructFields(sw, value.(map[interface{}]interface{}), fieldPath, tagger, order)
+			sw.closeStructField(tag)
+			sw.addLineComment(fieldPath)
+			return
 		}
+
+		sw.addStructField(keyName, fmt.Sprintf("%v", value), tag)
+		sw.setOrigin(keyName, objectName)
+		sw.addLineComment(fieldPath)
 	})
+}
 
-	return sw.parse()
+// the key a line of the source code originates from
+type sourceOrigin struct {
+	keyName        string
+	parentItemName string
 }
 
 type sourceWriter struct {
 	sourceCode string
+	// origins of the lines of sourceCode
+	origins map[int]sourceOrigin
+	// the comments written around the keys, keyed by their path; may be nil
+	comments declarationPositions
 }
 
-func newSourceWriter() *sourceWriter {
-	return &sourceWriter{"package " + mockPackageName + "\n"}
+func newSourceWriter(comments declarationPositions) *sourceWriter {
+	return &sourceWriter{"package " + mockPackageName + "\n", make(map[int]sourceOrigin), comments}
 }
 
-func (s *sourceWriter) parse() *ast.File {
-	file, _ := parser.ParseFile(token.NewFileSet(), "", s.sourceCode, 0)
+func (s *sourceWriter) parse(fileSet *token.FileSet) *ast.File {
+	file, _ := parser.ParseFile(fileSet, "", s.sourceCode, parser.ParseComments)
 	return file
 }
 
+// the line the last addition was written to
+func (s *sourceWriter) line() int {
+	return strings.Count(s.sourceCode, "\n") + 1
+}
+
+// marks the key the last addition originates from
+func (s *sourceWriter) setOrigin(keyName, parentItemName string) {
+	s.origins[s.line()] = sourceOrigin{keyName, parentItemName}
+}
+
+// writes the comment above the key with the given path, so it becomes the doc comment of the next addition
+func (s *sourceWriter) addDocComment(path string) *sourceWriter {
+	for _, line := range goCommentLines(s.comments[path].headComment) {
+		s.sourceCode = fmt.Sprintf("%s\n%s", s.sourceCode, line)
+	}
+	return s
+}
+
+// writes the comment beside the key with the given path behind the last addition, so it becomes its line comment
+func (s *sourceWriter) addLineComment(path string) *sourceWriter {
+	if lines := goCommentLines(s.comments[path].lineComment); len(lines) > 0 {
+		s.sourceCode = fmt.Sprintf("%s %s", s.sourceCode, lines[0])
+	}
+	return s
+}
+
+// "# foo\n\n# bar" => ["// foo", "//", "// bar"]; empty lines are kept as empty comment lines,
+// as they would split the comment into several groups of which only the last is attached
+func goCommentLines(yamlComment string) (lines []string) {
+	if yamlComment == "" {
+		return nil
+	}
+	for _, line := range strings.Split(yamlComment, "\n") {
+		text := strings.TrimPrefix(strings.TrimSpace(line), "#")
+		if text != "" && !strings.HasPrefix(text, " ") {
+			text = " " + text
+		}
+		lines = append(lines, "//"+strings.TrimRight(text, " \t\r"))
+	}
+	return
+}
+
+func (s *sourceWriter) addImport(name, importPath string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\nimport %s", s.sourceCode, importSpecSource(name, importPath))
+	return s
+}
+
+// adds an import spec to the block started by startDeclarationBlock("import")
+func (s *sourceWriter) addImportSpec(name, importPath string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n%s", s.sourceCode, importSpecSource(name, importPath))
+	return s
+}
+
+// the package is only named explicitly if its name differs from the last element of the path
+func importSpecSource(name, importPath string) string {
+	if name == defaultPackageName(importPath) {
+		return fmt.Sprintf("%q", importPath)
+	}
+	return fmt.Sprintf("%s %q", name, importPath)
+}
+
+// starts a block of imports, constants or variables; keyword is "import", "const" or "var"
+func (s *sourceWriter) startDeclarationBlock(keyword string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n%s (", s.sourceCode, keyword)
+	return s
+}
+
+// typeName and valueExpr may be empty; constants without both repeat the previous expression ("iota")
+func (s *sourceWriter) addValueSpec(name, typeName, valueExpr string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n%s %s", s.sourceCode, name, typeName)
+	if valueExpr != "" {
+		s.sourceCode = fmt.Sprintf("%s = %s", s.sourceCode, valueExpr)
+	}
+	return s
+}
+
+func (s *sourceWriter) closeDeclarationBlock() *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n)", s.sourceCode)
+	return s
+}
+
+// adds source code which does not originate from a single key
+func (s *sourceWriter) addSource(source string) *sourceWriter {
+	s.sourceCode += source
+	return s
+}
+
 func (s *sourceWriter) addNamedType(name, typeName string) *sourceWriter {
 	s.sourceCode = fmt.Sprintf("%s\ntype %s %s", s.sourceCode, name, typeName)
 	return s
 }
 
+func (s *sourceWriter) addAliasType(name, typeName string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\ntype %s = %s", s.sourceCode, name, typeName)
+	return s
+}
+
 func (s *sourceWriter) startStructType(name string) *sourceWriter {
 	s.sourceCode = fmt.Sprintf("%s\ntype %s struct {", s.sourceCode, name)
 	return s
 }
 
-func (s *sourceWriter) addStructField(name, typeName string) *sourceWriter {
-	s.sourceCode = fmt.Sprintf("%s\n%s %s", s.sourceCode, name, typeName)
+func (s *sourceWriter) startInterfaceType(name string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\ntype %s interface {", s.sourceCode, name)
+	return s
+}
+
+func (s *sourceWriter) addMethod(name, signature string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n%s%s", s.sourceCode, name, signature)
+	return s
+}
+
+func (s *sourceWriter) closeInterfaceType() *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n}", s.sourceCode)
+	return s
+}
+
+func (s *sourceWriter) startStructField(name string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n%s struct {", s.sourceCode, name)
+	return s
+}
+
+func (s *sourceWriter) addStructField(name, typeName, tag string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n%s %s %s", s.sourceCode, name, typeName, tag)
+	return s
+}
+
+func (s *sourceWriter) addEmbeddedField(typeName string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n%s", s.sourceCode, typeName)
+	return s
+}
+
+func (s *sourceWriter) closeStructField(tag string) *sourceWriter {
+	s.sourceCode = fmt.Sprintf("%s\n} %s", s.sourceCode, tag)
 	return s
 }
 
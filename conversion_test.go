@@ -53,7 +53,7 @@ func printDecls(decls []ast.Decl) string {
 }
 
 func printDeclsFromYamlData(inputYamlData map[interface{}]interface{}) string {
-	golangAST := convertToAST(inputYamlData)
+	golangAST := convertToAST(inputYamlData, nil, nil, nil, token.NewFileSet())
 	golangDecls := printDecls(golangAST.Decls)
 	return golangDecls
 }
@@ -87,6 +87,287 @@ func TestConvertToASTBasicCases(t *testing.T) {
 
 		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
 	})
+
+	t.Run("should convert nested objects to anonymous struct types", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": "int",
+				"baz": map[interface{}]interface{}{
+					"ban": "string",
+					"bam": map[interface{}]interface{}{
+						"bal": "bool",
+					},
+				},
+			},
+		}
+		expectedOutput := `
+		type foo struct {
+			bar int
+			baz struct {
+				bam struct{ bal bool }
+				ban string
+			}
+		}`
+
+		normalizedActualOutput := normalizeWhitespace(printDeclsFromYamlData(input))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+	})
+
+	t.Run("should convert struct types with tags", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"barID": "int",
+				"baz": map[interface{}]interface{}{
+					"banName": "string",
+				},
+			},
+		}
+		tagger := newStructTagger([]StructTag{{Key: "json", Naming: SnakeCase}}, nil)
+		expectedOutput := `
+		type foo struct {
+			barID int ` + "`json:\"bar_id\"`" + `
+			baz struct {
+				banName string ` + "`json:\"ban_name\"`" + `
+			} ` + "`json:\"baz\"`" + `
+		}`
+
+		normalizedActualOutput := normalizeWhitespace(printDecls(convertToAST(input, tagger, nil, nil, token.NewFileSet()).Decls))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+	})
+
+	t.Run("should convert embedded fields", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar":  nil,
+				"*baz": nil,
+				"ban":  "int",
+			},
+		}
+		tagger := newStructTagger([]StructTag{{Key: "json"}}, nil)
+		expectedOutput := `
+		type foo struct {
+			*baz
+			ban int ` + "`json:\"ban\"`" + `
+			bar
+		}`
+
+		normalizedActualOutput := normalizeWhitespace(printDecls(convertToAST(input, tagger, nil, nil, token.NewFileSet()).Decls))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+	})
+
+	t.Run("should convert interface types", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			"foo": interfaceDeclaration{
+				"Read":  "func(p []byte) (n int, err error)",
+				"Close": "func  () error",
+				"bar":   nil,
+			},
+		}
+		expectedOutput := `
+		type foo interface {
+			Close() error
+			Read(p []byte) (n int, err error)
+			bar
+		}`
+
+		normalizedActualOutput := normalizeWhitespace(printDeclsFromYamlData(input))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+	})
+
+	t.Run("should convert enums", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			"status": []interface{}{
+				map[interface{}]interface{}{"active": 1},
+				"inactive",
+			},
+		}
+		expectedOutput := `
+		import "fmt"
+		type status int
+		const (
+			statusActive   status = 1
+			statusInactive status = 2
+		)
+		func (s status) String() string {
+			switch s {
+			case statusActive:
+				return "active"
+			case statusInactive:
+				return "inactive"
+			}
+			return fmt.Sprintf("status(%d)", int(s))
+		}
+		func parseStatus(s string) (status, error) {
+			switch s {
+			case "active":
+				return statusActive, nil
+			case "inactive":
+				return statusInactive, nil
+			}
+			return 0, fmt.Errorf("invalid status %q", s)
+		}
+		func (s status) IsValid() bool {
+			switch s {
+			case statusActive, statusInactive:
+				return true
+			}
+			return false
+		}
+		func (s status) MarshalText() ([]byte, error) {
+			if !s.IsValid() {
+				return nil, fmt.Errorf("invalid status %d", int(s))
+			}
+			return []byte(s.String()), nil
+		}
+		func (s *status) UnmarshalText(text []byte) error {
+			value, err := parseStatus(string(text))
+			if err != nil {
+				return err
+			}
+			*s = value
+			return nil
+		}`
+
+		normalizedActualOutput := normalizeWhitespace(printDeclsFromYamlData(input))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+	})
+
+	t.Run("should declare enum constants with iota", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			"color": []interface{}{"red", "green", "blue"},
+		}
+		expectedOutput := `
+		type color int
+		const (
+			colorRed color = iota
+			colorGreen
+			colorBlue
+		)`
+
+		// the methods are left out, as they are in the type check
+		normalizedActualOutput := normalizeWhitespace(printDecls(writeSourceCode(input, nil, nil, nil, false).parse(token.NewFileSet()).Decls))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+	})
+
+	t.Run("should convert constants and variables", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			"const": valueSection{
+				"maxPlayers": 4,
+				"timeout":    map[interface{}]interface{}{"type": "int64", "value": 30},
+			},
+			"var": valueSection{
+				"board":   "[maxPlayers]string",
+				"retries": 3,
+			},
+			"foo": "string",
+		}
+		expectedOutput := `
+		const (
+			maxPlayers       = 4
+			timeout    int64 = 30
+		)
+		var (
+			board   [maxPlayers]string
+			retries = 3
+		)
+		type foo string`
+
+		normalizedActualOutput := normalizeWhitespace(printDeclsFromYamlData(input))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+	})
+
+	t.Run("should convert aliases", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			"foo": aliasDeclaration("bar"),
+			"bar": "[]int",
+		}
+		expectedOutput := `
+		type bar []int
+		type foo = bar`
+
+		decls := convertToAST(input, nil, nil, nil, token.NewFileSet()).Decls
+		normalizedActualOutput := normalizeWhitespace(printDecls(decls))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+		assert.True(t, decls[1].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Assign.IsValid())
+	})
+
+	t.Run("should convert generic types", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			genericTypeName{"list", "[T any]"}: map[interface{}]interface{}{
+				"items": "[]T",
+			},
+			genericTypeName{"number", "[T ~int | ~float64]"}: "[]T",
+			"foo": map[interface{}]interface{}{
+				"bar": "list[string]",
+				"baz": "number[int]",
+			},
+		}
+		expectedOutput := `
+		type foo struct {
+			bar list[string]
+			baz number[int]
+		}
+		type list[T any] struct{ items []T }
+		type number[T ~int | ~float64] []T`
+
+		decls := convertToAST(input, nil, nil, nil, token.NewFileSet()).Decls
+		normalizedActualOutput := normalizeWhitespace(printDecls(decls))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+		assert.Equal(t, 1, decls[1].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).TypeParams.NumFields())
+	})
+
+	t.Run("should import the used external packages", func(t *testing.T) {
+		input := map[interface{}]interface{}{
+			"import": importSection{
+				"time": map[interface{}]interface{}{
+					"types": map[interface{}]interface{}{"Time": "comparable"},
+				},
+				"yaml": map[interface{}]interface{}{
+					"path":  "gopkg.in/yaml.v3",
+					"types": map[interface{}]interface{}{"Node": "incomparable"},
+				},
+				"bytes": map[interface{}]interface{}{
+					"types": map[interface{}]interface{}{"Buffer": "incomparable"},
+				},
+			},
+			"foo": map[interface{}]interface{}{
+				"bar": "time.Time",
+				"baz": "*yaml.Node",
+			},
+		}
+		expectedOutput := `
+		import (
+			yaml "gopkg.in/yaml.v3"
+			"time"
+		)
+		type foo struct {
+			bar time.Time
+			baz *yaml.Node
+		}`
+
+		normalizedActualOutput := normalizeWhitespace(printDeclsFromYamlData(input))
+		normalizedExpectedOutput := normalizeWhitespace(expectedOutput)
+
+		assert.Equal(t, normalizedActualOutput, normalizedExpectedOutput)
+	})
 }
 
 func TestRangeInAlphabeticalOrder(t *testing.T) {
@@ -112,3 +393,12 @@ func TestRangeInAlphabeticalOrder(t *testing.T) {
 
 	})
 }
+
+func TestGoCommentLines(t *testing.T) {
+	t.Run("should convert YAML comments to line comments", func(t *testing.T) {
+		assert.Equal(t, []string{"// foo"}, goCommentLines("# foo"))
+		assert.Equal(t, []string{"// foo", "//", "// bar"}, goCommentLines("# foo\n\n# bar"))
+		assert.Equal(t, []string{"// foo", "//  indented"}, goCommentLines("#foo\n#  indented"))
+		assert.Empty(t, goCommentLines(""))
+	})
+}
@@ -2,7 +2,7 @@ package yamltostruct
 
 import (
 	"fmt"
-	"regexp"
+	"strings"
 )
 
 type pathClosureKind int
@@ -11,7 +11,7 @@ const (
 	pathClosureKindUndetermined pathClosureKind = iota
 	// path ends due to it being recusrive
 	pathClosureKindRecursiveness
-	// path ends due to encountering a reference value (*string, []string)
+	// path ends due to encountering a reference value (*string, []string, func() string, chan string)
 	pathClosureKindReference
 	// path ends due to encountering a basic type (string, int)
 	pathClosureKindBasicType
@@ -77,34 +77,34 @@ func (path *declarationPath) addDeclaration(
 }
 
 // we list the declarations' typeNames with some additional logic
-// to be more explicit (paths to nested field names will
-// be concatenated eg. "foo.bar")
+// to be more explicit (paths to field names will be
+// concatenated with the objects they are nested in eg. "foo.bar.baz")
 func (path declarationPath) joinedNames() []string {
 	var joinedNames []string
 
-	var wasStructDecl bool
-	var parentStructName string
+	// the object declarations leading to the current declaration
+	var objectNames []string
 
 	for _, declaration := range path.declarations {
 		if declaration.yamlValueKind == valueKindObject {
-			wasStructDecl = true
-			parentStructName = declaration.name
+			// the document itself is the object of level zero
+			if declaration.fieldLevel != fieldLevelZero {
+				objectNames = append(objectNames, declaration.name)
+			}
 			continue
 		}
 
-		if wasStructDecl && declaration.fieldLevel == secondFieldLevel {
-			joinedNames = append(joinedNames, parentStructName+"."+declaration.name)
+		if len(objectNames) > 0 && int(declaration.fieldLevel) == len(objectNames)+1 {
+			joinedNames = append(joinedNames, strings.Join(objectNames, ".")+"."+declaration.name)
 		} else {
 			joinedNames = append(joinedNames, declaration.name)
 		}
-		wasStructDecl = false
-		parentStructName = ""
-
+		objectNames = nil
 	}
 
 	// if path ended on struct declaration (it can happen if it's a recursive path)
-	if wasStructDecl {
-		joinedNames = append(joinedNames, parentStructName)
+	if len(objectNames) > 0 {
+		joinedNames = append(joinedNames, strings.Join(objectNames, "."))
 	}
 
 	return joinedNames
@@ -162,12 +162,16 @@ func (pb *pathBuilder) build(path declarationPath, keyName string, value interfa
 			return
 		}
 
-		// we extract the type so literals describing simple arrays like "[23]foo" become "foo"
-		// this only ever has an effect on arrays because all other types would be either reference types
-		// (e.g. "[]foo" or "map[foo]bar") and returned above, or named types like "foo"
-		nextTypeLiteral := extractTypes(valueLiteral)[0]
-		nextValue := pb.yamlData[nextTypeLiteral]
-		pb.build(path, nextTypeLiteral, nextValue, firstFieldLevel)
+		// we extract the types so literals describing arrays like "[23]foo" become "foo";
+		// anonymous structs like "struct{ a foo; b bar }" contain several types, so the path is forked
+		typeExpr, err := parseTypeExpr(valueLiteral)
+		if err != nil {
+			return
+		}
+		for _, nextTypeLiteral := range typeNamesContainedByValue(typeExpr) {
+			nextValue, _ := declarationOf(pb.yamlData, nextTypeLiteral)
+			pb.build(path.copySelf(), nextTypeLiteral, nextValue, firstFieldLevel)
+		}
 	}
 
 	if isMap(value) {
@@ -179,13 +183,33 @@ func (pb *pathBuilder) build(path declarationPath, keyName string, value interfa
 			return
 		}
 		mapValue := value.(map[interface{}]interface{})
-		for _key, _value := range mapValue {
+		rangeInAlphabeticalOrder(mapValue, func(_keyName string, _value interface{}) {
+			// an embedded field contains the type its key refers to
+			if isEmbeddedField(_value) && fieldLevel != fieldLevelZero {
+				_value = _keyName
+			}
 			// the path is copied; this is basically a fork
 			pathCopy := path.copySelf()
-			_keyName := fmt.Sprintf("%v", _key)
 			// we go a level deeper (fieldLevel+1) and handle each key/value
 			// pair in the next pb.build() execution
 			pb.build(pathCopy, _keyName, _value, fieldLevel+1)
+		})
+	}
+}
+
+// aliases cannot refer to themselves at all, not even through reference types ("a: = *b", "b: = []a");
+// starting at an alias, the path is built along all types it refers to as long as these are aliases as well
+func (pb *pathBuilder) buildAliasPath(path declarationPath, keyName string, value interface{}) {
+	path.addDeclaration(keyName, valueKindString, firstFieldLevel, value)
+	if path.isRecursive() {
+		path.setClosureKind(pathClosureKindRecursiveness)
+		pb.addPath(path)
+		return
+	}
+
+	for _, typeName := range extractTypes(fmt.Sprintf("%v", value)) {
+		if nextValue, _ := declarationOf(pb.yamlData, typeName); isAlias(nextValue) {
+			pb.buildAliasPath(path.copySelf(), typeName, nextValue)
 		}
 	}
 }
@@ -200,32 +224,27 @@ func isBasicType(typeString string) bool {
 }
 
 func containsOnlyBasicTypes(declarationTypeString string) bool {
-	extractTypes := extractTypes(declarationTypeString)
-	for _, extractType := range extractTypes {
-		if !isBasicType(extractType) {
-			return false
-		}
+	typeExpr, err := parseTypeExpr(declarationTypeString)
+	if err != nil {
+		return false
 	}
-	return true
-}
 
-// TODO: this should be revisited at some point
-func isReferenceType(declarationTypeString string) bool {
-	re := regexp.MustCompile(`\[\]|\*|map\[`)
-	return re.MatchString(declarationTypeString)
+	onlyBasicTypes := true
+	walkTypeReferences(typeExpr, func(name string, isArrayLength bool) {
+		// "[n]string" consists only of basic types, no matter what n is
+		if !isArrayLength && !isBasicType(name) {
+			onlyBasicTypes = false
+		}
+	})
+	return onlyBasicTypes
 }
 
-func containsUncomparableValue(typeName string, yamlData map[interface{}]interface{}) bool {
-	pathBuilder := newPathBuilder(yamlData)
-
-	pathBuilder.build(declarationPath{}, typeName, yamlData[typeName], firstFieldLevel)
-
-	var isUncomparable bool
-	for _, path := range pathBuilder.paths {
-		if path.closureKind == pathClosureKindRecursiveness || path.closureKind == pathClosureKindReference {
-			isUncomparable = true
-		}
+// a type is a reference type if its values do not contain values of any
+// other type ("*foo", "[]foo", "map[foo]bar", "func() foo", "chan foo")
+func isReferenceType(declarationTypeString string) bool {
+	typeExpr, err := parseTypeExpr(declarationTypeString)
+	if err != nil {
+		return false
 	}
-
-	return isUncomparable
+	return len(typeNamesContainedByValue(typeExpr)) == 0
 }
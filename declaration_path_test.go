@@ -438,6 +438,68 @@ func TestPathBuilder(t *testing.T) {
 		assert.Contains(t, joinedNamess, []string{"baz", "bar.foo", "[23][]buf"})
 	})
 
+	t.Run("should fork paths on anonymous structs in value strings", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"baz": "struct{ a bar; b buf; c func() baz }",
+			"bar": "string",
+			"buf": "[2]int",
+		}
+
+		pb := pathBuilder{
+			paths:    []declarationPath{},
+			yamlData: data,
+		}
+
+		pb.build(declarationPath{}, "baz", data["baz"], firstFieldLevel)
+
+		assert.Equal(t, 2, len(pb.paths))
+		joinedNamess := [][]string{
+			pb.paths[0].joinedNames(),
+			pb.paths[1].joinedNames(),
+		}
+
+		assert.Contains(t, joinedNamess, []string{"baz", "bar", "string"})
+		assert.Contains(t, joinedNamess, []string{"baz", "buf", "[2]int"})
+	})
+
+	t.Run("should build alias paths through reference types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": aliasDeclaration("*bar"),
+			"bar": aliasDeclaration("map[string]foo"),
+			"baz": map[interface{}]interface{}{
+				"ban": "foo",
+			},
+		}
+
+		pb := pathBuilder{
+			paths:    []declarationPath{},
+			yamlData: data,
+		}
+
+		pb.buildAliasPath(declarationPath{}, "foo", data["foo"])
+
+		assert.Equal(t, 1, len(pb.paths))
+		assert.Equal(t, []string{"foo", "bar", "foo"}, pb.paths[0].joinedNames())
+		assert.Equal(t, pathClosureKindRecursiveness, pb.paths[0].closureKind)
+	})
+
+	t.Run("should end alias paths at defined types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": aliasDeclaration("*bar"),
+			"bar": map[interface{}]interface{}{
+				"ban": "foo",
+			},
+		}
+
+		pb := pathBuilder{
+			paths:    []declarationPath{},
+			yamlData: data,
+		}
+
+		pb.buildAliasPath(declarationPath{}, "foo", data["foo"])
+
+		assert.Empty(t, pb.paths)
+	})
 }
 
 func TestDeclarationPath(t *testing.T) {
@@ -502,62 +564,14 @@ func TestIsReferenceType(t *testing.T) {
 		assert.Equal(t, isReferenceType("map[*string]string"), true)
 		assert.Equal(t, isReferenceType("map[int][23]string"), true)
 		assert.Equal(t, isReferenceType("map[[23]int]string"), true)
-	})
-}
-
-func TestContainsUncomparableValue(t *testing.T) {
-	t.Run("should not find reference value (1/2)", func(t *testing.T) {
-		data := map[interface{}]interface{}{
-			"foo": "string",
-			"bar": "foo",
-		}
-
-		assert.Equal(t, containsUncomparableValue("bar", data), false)
-	})
-	t.Run("should not find reference value (2/2)", func(t *testing.T) {
-		data := map[interface{}]interface{}{
-			"foo": "bar",
-			"bar": map[interface{}]interface{}{
-				"ban": "string",
-				"baz": "int",
-			},
-		}
-
-		assert.Equal(t, containsUncomparableValue("foo", data), false)
-	})
-	t.Run("should find reference value (1/4)", func(t *testing.T) {
-		data := map[interface{}]interface{}{
-			"foo": "[]string",
-			"bar": "foo",
-		}
-
-		assert.Equal(t, containsUncomparableValue("bar", data), true)
-	})
-	t.Run("should find reference value (2/4)", func(t *testing.T) {
-		data := map[interface{}]interface{}{
-			"foo": "map[int]string",
-			"bar": "foo",
-		}
-
-		assert.Equal(t, containsUncomparableValue("bar", data), true)
-	})
-	t.Run("should find reference value (3/4)", func(t *testing.T) {
-		data := map[interface{}]interface{}{
-			"foo": "*string",
-			"bar": "foo",
-		}
-
-		assert.Equal(t, containsUncomparableValue("bar", data), true)
-	})
-	t.Run("should find reference value (4/4)", func(t *testing.T) {
-		data := map[interface{}]interface{}{
-			"foo": "bar",
-			"bar": map[interface{}]interface{}{
-				"ban": "[]string",
-				"baz": "int",
-			},
-		}
-
-		assert.Equal(t, containsUncomparableValue("foo", data), true)
+		assert.Equal(t, isReferenceType("func(int) string"), true)
+		assert.Equal(t, isReferenceType("func(...foo) (foo, error)"), true)
+		assert.Equal(t, isReferenceType("[2]func() foo"), true)
+		assert.Equal(t, isReferenceType("chan foo"), true)
+		assert.Equal(t, isReferenceType("chan<- foo"), true)
+		assert.Equal(t, isReferenceType("<-chan foo"), true)
+		assert.Equal(t, isReferenceType("interface{ foo() foo }"), true)
+		assert.Equal(t, isReferenceType("struct{ a *foo }"), true)
+		assert.Equal(t, isReferenceType("struct{ a foo }"), false)
 	})
 }
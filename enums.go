@@ -0,0 +1,151 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// root level lists declare enums; each item names a value of the enum and
+// may assign an explicit integer to it ("- red" or "- red: 1")
+func isEnum(unknown interface{}) bool {
+	_, ok := unknown.([]interface{})
+	return ok
+}
+
+// the package the generated enum methods use
+const enumImportName = "fmt"
+
+type enumValue struct {
+	name  string
+	value int
+	// whether the value was assigned in the YAML document
+	explicit bool
+}
+
+// returns the values of the enum in the order they were declared; values without explicit
+// integer continue counting from the previous value, starting at 0.
+// Items which are neither names nor names with integers are skipped.
+func enumValuesOf(yamlEnumData []interface{}) (values []enumValue) {
+	next := 0
+	for _, item := range yamlEnumData {
+		name, value, explicit, ok := parseEnumItem(item)
+		if !ok {
+			continue
+		}
+		if !explicit {
+			value = next
+		}
+		values = append(values, enumValue{name, value, explicit})
+		next = value + 1
+	}
+	return
+}
+
+// "red" => ("red", 0, false, true), {"red": 1} => ("red", 1, true, true)
+func parseEnumItem(item interface{}) (name string, value int, explicit bool, ok bool) {
+	if isString(item) {
+		name = fmt.Sprintf("%v", item)
+		return name, 0, false, name != ""
+	}
+
+	mapValue, isMapItem := item.(map[interface{}]interface{})
+	if !isMapItem || len(mapValue) != 1 {
+		return "", 0, false, false
+	}
+	for key, itemValue := range mapValue {
+		name = fmt.Sprintf("%v", key)
+		value, ok = itemValue.(int)
+	}
+	return name, value, true, ok && name != ""
+}
+
+// iota can be used if no value was assigned explicitly
+func usesIota(values []enumValue) bool {
+	for _, value := range values {
+		if value.explicit {
+			return false
+		}
+	}
+	return true
+}
+
+// the constant declared for a value of an enum; ("color", "red") => "colorRed"
+func enumConstantName(enumName, valueName string) string {
+	return enumName + upperFirst(valueName)
+}
+
+// the function parsing the names of the values of an enum; "color" => "parseColor", "Color" => "ParseColor"
+func enumParseFuncName(enumName string) string {
+	if token.IsExported(enumName) {
+		return "Parse" + enumName
+	}
+	return "parse" + upperFirst(enumName)
+}
+
+// "color" => "c"
+func enumReceiverName(enumName string) string {
+	firstRune, _ := utf8.DecodeRuneInString(enumName)
+	if !unicode.IsLetter(firstRune) {
+		return "e"
+	}
+	return string(unicode.ToLower(firstRune))
+}
+
+func upperFirst(name string) string {
+	firstRune, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(firstRune)) + name[size:]
+}
+
+// writes String, IsValid, MarshalText and UnmarshalText methods and a parse function for the enum
+func enumMethodsSource(enumName string, values []enumValue) string {
+	receiver := enumReceiverName(enumName)
+	parseFuncName := enumParseFuncName(enumName)
+
+	var constantNames []string
+	var stringCases, parseCases strings.Builder
+	for _, value := range values {
+		constantName := enumConstantName(enumName, value.name)
+		constantNames = append(constantNames, constantName)
+		fmt.Fprintf(&stringCases, "\ncase %s:\nreturn %q", constantName, value.name)
+		fmt.Fprintf(&parseCases, "\ncase %q:\nreturn %s, nil", value.name, constantName)
+	}
+
+	validCase := ""
+	if len(constantNames) > 0 {
+		validCase = fmt.Sprintf("\ncase %s:\nreturn true", strings.Join(constantNames, ", "))
+	}
+
+	return fmt.Sprintf(`
+func (%[1]s %[2]s) String() string {
+switch %[1]s {%[4]s
+}
+return fmt.Sprintf("%[2]s(%%d)", int(%[1]s))
+}
+func %[3]s(s string) (%[2]s, error) {
+switch s {%[5]s
+}
+return 0, fmt.Errorf("invalid %[2]s %%q", s)
+}
+func (%[1]s %[2]s) IsValid() bool {
+switch %[1]s {%[6]s
+}
+return false
+}
+func (%[1]s %[2]s) MarshalText() ([]byte, error) {
+if !%[1]s.IsValid() {
+return nil, fmt.Errorf("invalid %[2]s %%d", int(%[1]s))
+}
+return []byte(%[1]s.String()), nil
+}
+func (%[1]s *%[2]s) UnmarshalText(text []byte) error {
+value, err := %[3]s(string(text))
+if err != nil {
+return err
+}
+*%[1]s = value
+return nil
+}`, receiver, enumName, parseFuncName, stringCases.String(), parseCases.String(), validCase)
+}
@@ -0,0 +1,60 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumValuesOf(t *testing.T) {
+	t.Run("should count values starting at 0", func(t *testing.T) {
+		values := enumValuesOf([]interface{}{"red", "green", "blue"})
+
+		assert.Equal(t, []enumValue{{"red", 0, false}, {"green", 1, false}, {"blue", 2, false}}, values)
+		assert.True(t, usesIota(values))
+	})
+
+	t.Run("should continue counting after explicit values", func(t *testing.T) {
+		values := enumValuesOf([]interface{}{
+			"unknown",
+			map[interface{}]interface{}{"active": 5},
+			"inactive",
+			map[interface{}]interface{}{"deleted": -1},
+			"archived",
+		})
+
+		assert.Equal(t, []enumValue{
+			{"unknown", 0, false},
+			{"active", 5, true},
+			{"inactive", 6, false},
+			{"deleted", -1, true},
+			{"archived", 0, false},
+		}, values)
+		assert.False(t, usesIota(values))
+	})
+
+	t.Run("should skip invalid items", func(t *testing.T) {
+		values := enumValuesOf([]interface{}{
+			"",
+			1,
+			nil,
+			map[interface{}]interface{}{"a": 1, "b": 2},
+			map[interface{}]interface{}{"c": "1"},
+			"red",
+		})
+
+		assert.Equal(t, []enumValue{{"red", 0, false}}, values)
+	})
+}
+
+func TestEnumNames(t *testing.T) {
+	t.Run("should generate names of constants and parse functions", func(t *testing.T) {
+		assert.Equal(t, "colorRed", enumConstantName("color", "red"))
+		assert.Equal(t, "ColorRed", enumConstantName("Color", "red"))
+		assert.Equal(t, "color_red", enumConstantName("color", "_red"))
+		assert.Equal(t, "parseColor", enumParseFuncName("color"))
+		assert.Equal(t, "ParseColor", enumParseFuncName("Color"))
+		assert.Equal(t, "c", enumReceiverName("Color"))
+		assert.Equal(t, "e", enumReceiverName("_color"))
+	})
+}
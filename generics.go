@@ -0,0 +1,134 @@
+package yamltostruct
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// root level keys may declare type parameters ("list[T any]", "pair[K comparable, V any]");
+// these keys are kept as genericTypeName, which formats as the name of the type,
+// so generic types are ranged over and reported just like any other type
+type genericTypeName struct {
+	name string
+	// the type parameter list as declared, including the brackets ("[K comparable, V any]")
+	typeParams string
+}
+
+func (g genericTypeName) String() string {
+	return g.name
+}
+
+func isGenericTypeName(key interface{}) bool {
+	_, ok := key.(genericTypeName)
+	return ok
+}
+
+// "list[T any]" => (genericTypeName{"list", "[T any]"}, true); keys which are no
+// identifier followed by a valid type parameter list are left to ErrIllegalTypeName
+func parseGenericTypeName(key string) (genericTypeName, bool) {
+	i := strings.Index(key, "[")
+	if i == -1 {
+		return genericTypeName{}, false
+	}
+	name, typeParams := key[:i], key[i:]
+	if !token.IsIdentifier(name) || len(parseTypeParams(typeParams)) == 0 {
+		return genericTypeName{}, false
+	}
+	return genericTypeName{name, typeParams}, true
+}
+
+type typeParam struct {
+	name string
+	// the constraint as declared ("comparable", "~int | ~string")
+	constraintString string
+	constraint       ast.Expr
+}
+
+// "K comparable"
+func (p typeParam) String() string {
+	return p.name + " " + p.constraintString
+}
+
+// parses a type parameter list ("[K comparable, V any]"); returns nil if it is invalid.
+// The list is spliced into a type declaration, so anything following the list
+// ("[T any] int; func init() {}") is rejected just like in parseTypeExpr
+func parseTypeParams(typeParams string) []typeParam {
+	if containsComment(typeParams) {
+		return nil
+	}
+	const declarationPrefix = "package " + mockPackageName + "\ntype t"
+	sourceCode := declarationPrefix + typeParams + " int"
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", sourceCode, 0)
+	if err != nil || len(file.Decls) != 1 {
+		return nil
+	}
+	genDecl, ok := file.Decls[0].(*ast.GenDecl)
+	if !ok || len(genDecl.Specs) != 1 {
+		return nil
+	}
+	typeSpec, ok := genDecl.Specs[0].(*ast.TypeSpec)
+	// positions of parser.ParseFile start at 1, so the closing bracket has to be the last character of the list
+	if !ok || typeSpec.TypeParams == nil || int(typeSpec.TypeParams.Closing) != len(declarationPrefix)+len(typeParams) {
+		return nil
+	}
+
+	var params []typeParam
+	for _, field := range typeSpec.TypeParams.List {
+		constraintString := exprSource(sourceCode, field.Type)
+		for _, name := range field.Names {
+			params = append(params, typeParam{name.Name, constraintString, field.Type})
+		}
+	}
+	return params
+}
+
+// the key the type is declared with in yamlData; generic types are declared with their type parameters
+func declarationKeyOf(yamlData map[interface{}]interface{}, typeName string) (interface{}, bool) {
+	if _, ok := yamlData[typeName]; ok {
+		return typeName, true
+	}
+	for key := range yamlData {
+		if generic, ok := key.(genericTypeName); ok && generic.name == typeName {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// returns the value the type is declared with, generic or not
+func declarationOf(yamlData map[interface{}]interface{}, typeName string) (interface{}, bool) {
+	key, ok := declarationKeyOf(yamlData, typeName)
+	if !ok {
+		return nil, false
+	}
+	return yamlData[key], true
+}
+
+// returns the type parameters of the type; nil if it is not generic (or not declared)
+func typeParamsOf(yamlData map[interface{}]interface{}, typeName string) []typeParam {
+	key, _ := declarationKeyOf(yamlData, typeName)
+	if generic, ok := key.(genericTypeName); ok {
+		return parseTypeParams(generic.typeParams)
+	}
+	return nil
+}
+
+// the name of the type including its type parameter list, as it is written in the type declaration
+// "list" => "list[T any]"
+func typeSpecNameOf(yamlData map[interface{}]interface{}, typeName string) string {
+	key, _ := declarationKeyOf(yamlData, typeName)
+	if generic, ok := key.(genericTypeName); ok {
+		return generic.name + generic.typeParams
+	}
+	return typeName
+}
+
+func typeParamNames(params []typeParam) (names []string) {
+	for _, param := range params {
+		names = append(names, param.name)
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGenericTypeName(t *testing.T) {
+	t.Run("should split the name from the type parameters", func(t *testing.T) {
+		for key, expectedGenericTypeName := range map[string]genericTypeName{
+			"list[T any]":               {"list", "[T any]"},
+			"pair[K comparable, V any]": {"pair", "[K comparable, V any]"},
+			"number[T ~int | ~float64]": {"number", "[T ~int | ~float64]"},
+			"slice[S ~[]E, E any]":      {"slice", "[S ~[]E, E any]"},
+			"show[T interface{ M() }]":  {"show", "[T interface{ M() }]"},
+			"grid[X, Y comparable]":     {"grid", "[X, Y comparable]"},
+		} {
+			genericTypeName, ok := parseGenericTypeName(key)
+			assert.True(t, ok, key)
+			assert.Equal(t, expectedGenericTypeName, genericTypeName, key)
+		}
+	})
+
+	t.Run("should reject keys which are no name with type parameter list", func(t *testing.T) {
+		for _, key := range []string{
+			"list",
+			"list[T]",
+			"list[]",
+			"list[2]",
+			"[T any]",
+			"my list[T any]",
+			"list[T any] int; func init() {}",
+			"list[T any] = int\ntype evil[T any]",
+			"list[T any] // comment",
+			"list[T any /* comment */]",
+		} {
+			_, ok := parseGenericTypeName(key)
+			assert.False(t, ok, key)
+		}
+	})
+
+	t.Run("should format as the name of the type", func(t *testing.T) {
+		genericTypeName, _ := parseGenericTypeName("list[T any]")
+		assert.Equal(t, "list", genericTypeName.String())
+	})
+}
+
+func TestParseTypeParams(t *testing.T) {
+	t.Run("should parse names and constraints of type parameters", func(t *testing.T) {
+		typeParams := parseTypeParams("[K, L comparable, V ~int | string]")
+
+		assert.Equal(t, []string{"K", "L", "V"}, typeParamNames(typeParams))
+		assert.Equal(t, []string{"K comparable", "L comparable", "V ~int | string"}, typeParamStrings(typeParams))
+	})
+}
+
+func TestDeclarationOf(t *testing.T) {
+	data := map[interface{}]interface{}{
+		"user":                             "string",
+		genericTypeName{"list", "[T any]"}: map[interface{}]interface{}{"items": "[]T"},
+	}
+
+	t.Run("should find generic and non-generic declarations by name", func(t *testing.T) {
+		value, ok := declarationOf(data, "user")
+		assert.True(t, ok)
+		assert.Equal(t, "string", value)
+
+		value, ok = declarationOf(data, "list")
+		assert.True(t, ok)
+		assert.Equal(t, map[interface{}]interface{}{"items": "[]T"}, value)
+
+		_, ok = declarationOf(data, "foo")
+		assert.False(t, ok)
+	})
+
+	t.Run("should return the type parameters of generic types", func(t *testing.T) {
+		assert.Equal(t, []string{"T"}, typeParamNames(typeParamsOf(data, "list")))
+		assert.Empty(t, typeParamsOf(data, "user"))
+		assert.Equal(t, "list[T any]", typeSpecNameOf(data, "list"))
+		assert.Equal(t, "user", typeSpecNameOf(data, "user"))
+	})
+}
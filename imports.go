@@ -0,0 +1,219 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// the reserved key of the root level which declares the external packages whose types
+// can be used as qualified identifiers ("time.Time"); it is a keyword, so it cannot be used as type name anyway
+const importSectionKey = "import"
+
+// the keys of a package declaration ("uuid: {path: github.com/google/uuid, types: {UUID: comparable}}")
+const (
+	importPathKey  = "path"
+	importTypesKey = "types"
+)
+
+// the values the types of a package are declared with
+const (
+	comparableTypeValue   = "comparable"
+	incomparableTypeValue = "incomparable"
+)
+
+// the packages declared under the reserved key; each key is the name the package is referred to by
+type importSection map[interface{}]interface{}
+
+func isImportSection(unknown interface{}) bool {
+	_, ok := unknown.(importSection)
+	return ok
+}
+
+// keys of the root level which declare no types
+func isReservedKey(keyName string) bool {
+	return isSectionKey(keyName) || keyName == importSectionKey
+}
+
+type externalPackage struct {
+	name string
+	path string
+	// the types of the package and whether they are comparable
+	types map[string]bool
+}
+
+// parses the declaration of an external package; the path defaults to the name of the package ("time")
+func externalPackageOf(name string, value interface{}) (externalPackage, bool) {
+	externalPkg := externalPackage{name: name, path: name, types: make(map[string]bool)}
+
+	mapValue, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return externalPackage{}, false
+	}
+	for key, item := range mapValue {
+		switch fmt.Sprintf("%v", key) {
+		case importPathKey:
+			if !isString(item) || isEmptyString(item) {
+				return externalPackage{}, false
+			}
+			externalPkg.path = fmt.Sprintf("%v", item)
+		case importTypesKey:
+			typesValue, ok := item.(map[interface{}]interface{})
+			if !ok {
+				return externalPackage{}, false
+			}
+			for typeKey, comparability := range typesValue {
+				isComparable, ok := parseComparability(comparability)
+				if !ok {
+					return externalPackage{}, false
+				}
+				externalPkg.types[fmt.Sprintf("%v", typeKey)] = isComparable
+			}
+		default:
+			return externalPackage{}, false
+		}
+	}
+
+	return externalPkg, true
+}
+
+// "comparable" => (true, true), "incomparable" => (false, true)
+func parseComparability(value interface{}) (isComparable bool, ok bool) {
+	switch value {
+	case comparableTypeValue:
+		return true, true
+	case incomparableTypeValue:
+		return false, true
+	}
+	return false, false
+}
+
+// returns the valid package declarations in alphabetical order of their names
+func externalPackagesOf(yamlData map[interface{}]interface{}) (externalPackages []externalPackage) {
+	section, ok := yamlData[importSectionKey].(importSection)
+	if !ok {
+		return nil
+	}
+	rangeInAlphabeticalOrder(section, func(name string, value interface{}) {
+		if externalPkg, ok := externalPackageOf(name, value); ok {
+			externalPackages = append(externalPackages, externalPkg)
+		}
+	})
+	return
+}
+
+// returns whether the qualified identifier ("time.Time") names a declared external type and whether it is comparable
+func externalTypeOf(yamlData map[interface{}]interface{}, qualifiedName string) (isComparable bool, ok bool) {
+	packageName, typeName, isQualified := strings.Cut(qualifiedName, ".")
+	if !isQualified {
+		return false, false
+	}
+	for _, externalPkg := range externalPackagesOf(yamlData) {
+		if externalPkg.name == packageName {
+			isComparable, ok = externalPkg.types[typeName]
+			return isComparable, ok
+		}
+	}
+	return false, false
+}
+
+// the qualified identifiers of all declared external types ("time.Time"), sorted
+func externalTypeNames(yamlData map[interface{}]interface{}) (qualifiedNames []string) {
+	for _, externalPkg := range externalPackagesOf(yamlData) {
+		for typeName := range externalPkg.types {
+			qualifiedNames = append(qualifiedNames, externalPkg.name+"."+typeName)
+		}
+	}
+	sort.Strings(qualifiedNames)
+	return
+}
+
+// returns the packages referred to by the types declared in yamlData; only these are imported,
+// as go does not allow unused imports
+func usedExternalPackages(yamlData map[interface{}]interface{}) (usedPackages []externalPackage) {
+	usedNames := make(map[string]bool)
+	rangeTypeExpressions(yamlData, func(valueString, _, _ string) {
+		for _, typeName := range extractTypes(valueString) {
+			if packageName, _, isQualified := strings.Cut(typeName, "."); isQualified {
+				usedNames[packageName] = true
+			}
+		}
+	})
+
+	for _, externalPkg := range externalPackagesOf(yamlData) {
+		if usedNames[externalPkg.name] {
+			usedPackages = append(usedPackages, externalPkg)
+		}
+	}
+	return
+}
+
+// import paths are spliced into the generated source code quoted; go restricts them to
+// graphic characters without spaces and some punctuation (https://golang.org/ref/spec#Import_declarations)
+func isValidImportPath(importPath string) bool {
+	if importPath == "" {
+		return false
+	}
+	for _, r := range importPath {
+		if !unicode.IsGraphic(r) || unicode.IsSpace(r) || strings.ContainsRune("!\"#$%&'()*,:;<=>?[\\]^`{|}", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// the name a package is referred to by if its import spec does not name it ("github.com/google/uuid" => "uuid")
+func defaultPackageName(importPath string) string {
+	return path.Base(importPath)
+}
+
+// go/types cannot load the declared packages, so they are made up from their declarations;
+// the types are structs which are comparable or not, just like declared
+type externalImporter map[string]*types.Package
+
+func newExternalImporter(yamlData map[interface{}]interface{}) externalImporter {
+	// a package may be imported under several names, each declaring some of its types;
+	// the package has all of them, and those declared incomparable anywhere are incomparable
+	var paths []string
+	names := make(map[string]string)
+	typesByPath := make(map[string]map[string]bool)
+	for _, externalPkg := range externalPackagesOf(yamlData) {
+		if _, ok := typesByPath[externalPkg.path]; !ok {
+			paths = append(paths, externalPkg.path)
+			names[externalPkg.path] = externalPkg.name
+			typesByPath[externalPkg.path] = make(map[string]bool)
+		}
+		for typeName, isComparable := range externalPkg.types {
+			wasComparable, ok := typesByPath[externalPkg.path][typeName]
+			typesByPath[externalPkg.path][typeName] = isComparable && (wasComparable || !ok)
+		}
+	}
+
+	importer := make(externalImporter)
+	for _, importPath := range paths {
+		pkg := types.NewPackage(importPath, names[importPath])
+		for typeName, isComparable := range typesByPath[importPath] {
+			underlying := types.NewStruct(nil, nil)
+			if !isComparable {
+				incomparableField := types.NewField(0, pkg, "_", types.NewSlice(types.Typ[types.Int]), false)
+				underlying = types.NewStruct([]*types.Var{incomparableField}, nil)
+			}
+			typeObject := types.NewTypeName(0, pkg, typeName, nil)
+			types.NewNamed(typeObject, underlying, nil)
+			pkg.Scope().Insert(typeObject)
+		}
+		pkg.MarkComplete()
+		importer[importPath] = pkg
+	}
+	return importer
+}
+
+func (importer externalImporter) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := importer[importPath]; ok {
+		return pkg, nil
+	}
+	return nil, fmt.Errorf("package %q is not declared", importPath)
+}
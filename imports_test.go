@@ -0,0 +1,132 @@
+package yamltostruct
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalPackageOf(t *testing.T) {
+	t.Run("should parse package declarations", func(t *testing.T) {
+		externalPkg, ok := externalPackageOf("uuid", map[interface{}]interface{}{
+			"path": "github.com/google/uuid",
+			"types": map[interface{}]interface{}{
+				"UUID":     "comparable",
+				"NullUUID": "incomparable",
+			},
+		})
+
+		assert.True(t, ok)
+		assert.Equal(t, externalPackage{
+			name:  "uuid",
+			path:  "github.com/google/uuid",
+			types: map[string]bool{"UUID": true, "NullUUID": false},
+		}, externalPkg)
+	})
+
+	t.Run("should default the path to the name of the package", func(t *testing.T) {
+		externalPkg, ok := externalPackageOf("time", map[interface{}]interface{}{
+			"types": map[interface{}]interface{}{"Time": "comparable"},
+		})
+
+		assert.True(t, ok)
+		assert.Equal(t, "time", externalPkg.path)
+	})
+
+	t.Run("should reject invalid package declarations", func(t *testing.T) {
+		for _, value := range []interface{}{
+			nil,
+			"github.com/google/uuid",
+			map[interface{}]interface{}{"path": ""},
+			map[interface{}]interface{}{"types": []interface{}{"UUID"}},
+			map[interface{}]interface{}{"types": map[interface{}]interface{}{"UUID": true}},
+			map[interface{}]interface{}{"version": "v1"},
+		} {
+			_, ok := externalPackageOf("uuid", value)
+			assert.False(t, ok, value)
+		}
+	})
+}
+
+func TestExternalTypes(t *testing.T) {
+	data := map[interface{}]interface{}{
+		"import": importSection{
+			"time": map[interface{}]interface{}{
+				"types": map[interface{}]interface{}{"Time": "comparable", "Duration": "comparable"},
+			},
+			"yaml": map[interface{}]interface{}{
+				"path":  "gopkg.in/yaml.v3",
+				"types": map[interface{}]interface{}{"Node": "incomparable"},
+			},
+		},
+		"foo": map[interface{}]interface{}{
+			"bar": "*yaml.Node",
+		},
+	}
+
+	t.Run("should resolve qualified identifiers to declared external types", func(t *testing.T) {
+		isComparable, ok := externalTypeOf(data, "time.Time")
+		assert.True(t, ok)
+		assert.True(t, isComparable)
+
+		isComparable, ok = externalTypeOf(data, "yaml.Node")
+		assert.True(t, ok)
+		assert.False(t, isComparable)
+
+		_, ok = externalTypeOf(data, "time.Timer")
+		assert.False(t, ok)
+		_, ok = externalTypeOf(data, "Time")
+		assert.False(t, ok)
+	})
+
+	t.Run("should list the qualified identifiers of all external types", func(t *testing.T) {
+		assert.Equal(t, []string{"time.Duration", "time.Time", "yaml.Node"}, externalTypeNames(data))
+	})
+
+	t.Run("should only return the packages which are used", func(t *testing.T) {
+		usedPackages := usedExternalPackages(data)
+
+		assert.Equal(t, 1, len(usedPackages))
+		assert.Equal(t, "gopkg.in/yaml.v3", usedPackages[0].path)
+	})
+}
+
+func TestExternalImporter(t *testing.T) {
+	t.Run("should merge the types of packages imported under several names", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": importSection{
+				"a": map[interface{}]interface{}{
+					"path":  "example.com/x",
+					"types": map[interface{}]interface{}{"T": "comparable", "V": "comparable"},
+				},
+				"b": map[interface{}]interface{}{
+					"path":  "example.com/x",
+					"types": map[interface{}]interface{}{"U": "comparable", "V": "incomparable"},
+				},
+			},
+		}
+
+		pkg, err := newExternalImporter(data).Import("example.com/x")
+		assert.Nil(t, err)
+
+		assert.Equal(t, []string{"T", "U", "V"}, pkg.Scope().Names())
+		assert.True(t, types.Comparable(pkg.Scope().Lookup("T").Type()))
+		assert.True(t, types.Comparable(pkg.Scope().Lookup("U").Type()))
+		assert.False(t, types.Comparable(pkg.Scope().Lookup("V").Type()))
+	})
+}
+
+func TestIsValidImportPath(t *testing.T) {
+	t.Run("should accept import paths", func(t *testing.T) {
+		for _, importPath := range []string{"time", "net/http", "github.com/google/uuid", "gopkg.in/yaml.v3"} {
+			assert.True(t, isValidImportPath(importPath), importPath)
+		}
+	})
+
+	t.Run("should reject import paths with spaces, quotes and other restricted characters", func(t *testing.T) {
+		for _, importPath := range []string{"", "net http", "time\"; func init() {}; \"", "a\nb", "foo{bar}"} {
+			assert.False(t, isValidImportPath(importPath), importPath)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+)
+
+// returns warnings about declarations which are valid but most likely not intended;
+// warnings never prevent the generation of declarations unless they are promoted to errors
+func lintYamlData(yamlData map[interface{}]interface{}) (warnings []error) {
+
+	unusedTypeWarnings := lintUnusedTypes(yamlData)
+	warnings = append(warnings, unusedTypeWarnings...)
+
+	fieldShadowsTypeWarnings := lintFieldsShadowingTypes(yamlData)
+	warnings = append(warnings, fieldShadowsTypeWarnings...)
+
+	mixedExportedTypeNameWarnings := lintMixedExportedTypeNames(yamlData)
+	warnings = append(warnings, mixedExportedTypeNameWarnings...)
+
+	emptyStructWarnings := lintEmptyStructs(yamlData)
+	warnings = append(warnings, emptyStructWarnings...)
+
+	caseCollisionWarnings := lintNamesDifferingOnlyInCase(yamlData)
+	warnings = append(warnings, caseCollisionWarnings...)
+
+	return
+}
+
+// returns warnings for types that are not used in any other declaration
+func lintUnusedTypes(yamlData map[interface{}]interface{}) (warnings []error) {
+	usedTypes := make(map[string]bool)
+
+	// the types of constants and variables count as usages
+	rangeValueSections(yamlData, func(sectionKey string, section valueSection) {
+		rangeInAlphabeticalOrder(section, func(_ string, value interface{}) {
+			typeString, _, _ := valueDeclarationOf(sectionKey, value)
+			for _, usedType := range extractTypes(typeString) {
+				usedTypes[usedType] = true
+			}
+		})
+	})
+
+	rangeTypeDeclarations(yamlData, func(keyName string, value interface{}) {
+		markUsedTypes := func(typeNames []string) {
+			for _, usedType := range typeNames {
+				// using a type in its own declaration does not count
+				if usedType != keyName {
+					usedTypes[usedType] = true
+				}
+			}
+		}
+
+		var markUsed func(value interface{})
+		markUsed = func(value interface{}) {
+			if isInterface(value) {
+				value = map[interface{}]interface{}(value.(interfaceDeclaration))
+			}
+			if isMap(value) {
+				rangeInAlphabeticalOrder(value.(map[interface{}]interface{}), func(_keyName string, _value interface{}) {
+					// the key of an embedded field is the type it embeds
+					if isEmbeddedField(_value) {
+						_value = _keyName
+					}
+					markUsed(_value)
+				})
+				return
+			}
+			if !isString(value) {
+				return
+			}
+			markUsedTypes(extractTypes(fmt.Sprintf("%v", value)))
+		}
+
+		markUsed(value)
+		// the constraints of its type parameters count as usages as well
+		for _, param := range typeParamsOf(yamlData, keyName) {
+			markUsedTypes(extractConstraintTypes(param.constraint))
+		}
+	})
+
+	rangeTypeDeclarations(yamlData, func(keyName string, _ interface{}) {
+		if !usedTypes[keyName] {
+			warnings = append(warnings, newValidationWarningUnusedType(keyName))
+		}
+	})
+
+	return
+}
+
+// returns warnings for fields which have the same name as a declared type
+func lintFieldsShadowingTypes(yamlData map[interface{}]interface{}) (warnings []error) {
+	rangeObjects(yamlData, func(objectName string, yamlObjectData map[interface{}]interface{}) {
+		rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+			// embedded fields are named after their type by definition
+			if isEmbeddedField(value) {
+				return
+			}
+			if _, ok := declarationOf(yamlData, keyName); ok {
+				warnings = append(warnings, newValidationWarningFieldShadowsType(keyName, objectName))
+			}
+		})
+	})
+
+	return
+}
+
+// returns warnings for type names that are exported while most others are not (or vice versa)
+func lintMixedExportedTypeNames(yamlData map[interface{}]interface{}) (warnings []error) {
+	var exportedNames, unexportedNames []string
+
+	rangeTypeDeclarations(yamlData, func(keyName string, _ interface{}) {
+		if token.IsExported(keyName) {
+			exportedNames = append(exportedNames, keyName)
+		} else {
+			unexportedNames = append(unexportedNames, keyName)
+		}
+	})
+
+	if len(exportedNames) == 0 || len(unexportedNames) == 0 {
+		return
+	}
+
+	// the less common kind of names is reported
+	deviatingNames := exportedNames
+	if len(unexportedNames) < len(exportedNames) {
+		deviatingNames = unexportedNames
+	}
+
+	for _, deviatingName := range deviatingNames {
+		warnings = append(warnings, newValidationWarningMixedExportedTypeNames(deviatingName))
+	}
+
+	return
+}
+
+// returns warnings for objects without fields
+func lintEmptyStructs(yamlData map[interface{}]interface{}) (warnings []error) {
+	rangeObjects(yamlData, func(objectName string, yamlObjectData map[interface{}]interface{}) {
+		if len(yamlObjectData) == 0 {
+			keyName, parentItemName := splitDeclarationPath(objectName)
+			warnings = append(warnings, newValidationWarningEmptyStruct(keyName, parentItemName))
+		}
+	})
+
+	return
+}
+
+// returns warnings for type names and field names of the same object which only differ in case ("userID", "userId")
+func lintNamesDifferingOnlyInCase(yamlData map[interface{}]interface{}) (warnings []error) {
+	warnings = append(warnings, findNamesDifferingOnlyInCase(yamlData, "root")...)
+
+	rangeObjects(yamlData, func(objectName string, yamlObjectData map[interface{}]interface{}) {
+		warnings = append(warnings, findNamesDifferingOnlyInCase(yamlObjectData, objectName)...)
+	})
+
+	return
+}
+
+func findNamesDifferingOnlyInCase(yamlObjectData map[interface{}]interface{}, objectName string) (warnings []error) {
+	var previousNames []string
+
+	rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, _ interface{}) {
+		for _, previousName := range previousNames {
+			if strings.EqualFold(previousName, keyName) {
+				warnings = append(warnings, newValidationWarningNamesDifferOnlyInCase(keyName, previousName, objectName))
+				break
+			}
+		}
+		previousNames = append(previousNames, keyName)
+	})
+
+	return
+}
@@ -0,0 +1,190 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintYamlData(t *testing.T) {
+	t.Run("should not warn about well-formed declarations", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"name": "string",
+			"person": map[interface{}]interface{}{
+				"firstName": "name",
+				"friends":   "[]person",
+			},
+			"team": "map[name]person",
+		}
+
+		warnings := lintYamlData(data)
+
+		assert.Equal(t, []error{newValidationWarningUnusedType("team")}, warnings)
+	})
+
+	t.Run("should warn about unused types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "string",
+			"bar": "*bar",
+			"baz": map[interface{}]interface{}{
+				"ban": "foo",
+			},
+		}
+
+		warnings := lintUnusedTypes(data)
+
+		assert.Equal(t, []error{
+			newValidationWarningUnusedType("bar"),
+			newValidationWarningUnusedType("baz"),
+		}, warnings)
+	})
+
+	t.Run("should warn about fields shadowing types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"id": "string",
+			"person": map[interface{}]interface{}{
+				"id":   "id",
+				"name": "string",
+			},
+		}
+
+		warnings := lintFieldsShadowingTypes(data)
+
+		assert.Equal(t, []error{newValidationWarningFieldShadowsType("id", "person")}, warnings)
+	})
+
+	t.Run("should warn about type names deviating in exportedness", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"Foo": "string",
+			"bar": "string",
+			"baz": "string",
+		}
+
+		warnings := lintMixedExportedTypeNames(data)
+
+		assert.Equal(t, []error{newValidationWarningMixedExportedTypeNames("Foo")}, warnings)
+	})
+
+	t.Run("should warn about empty structs", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{},
+			"bar": map[interface{}]interface{}{
+				"ban": "string",
+			},
+		}
+
+		warnings := lintEmptyStructs(data)
+
+		assert.Equal(t, []error{newValidationWarningEmptyStruct("foo", "root")}, warnings)
+	})
+
+	t.Run("should warn about names only differing in case", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"userID": "string",
+			"userId": "string",
+			"person": map[interface{}]interface{}{
+				"Name": "string",
+				"name": "string",
+			},
+		}
+
+		warnings := lintNamesDifferingOnlyInCase(data)
+
+		assert.Equal(t, []error{
+			newValidationWarningNamesDifferOnlyInCase("userId", "userID", "root"),
+			newValidationWarningNamesDifferOnlyInCase("name", "Name", "person"),
+		}, warnings)
+	})
+
+	t.Run("should lint nested objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"id": "string",
+			"person": map[interface{}]interface{}{
+				"address": map[interface{}]interface{}{
+					"id":     "id",
+					"Street": "string",
+					"street": "string",
+					"extra":  map[interface{}]interface{}{},
+				},
+			},
+		}
+
+		warnings := lintYamlData(data)
+
+		assert.Equal(t, []error{
+			newValidationWarningUnusedType("person"),
+			newValidationWarningFieldShadowsType("id", "person.address"),
+			newValidationWarningEmptyStruct("extra", "person.address"),
+			newValidationWarningNamesDifferOnlyInCase("street", "Street", "person.address"),
+		}, warnings)
+	})
+
+	t.Run("should consider embedded types as used and not as shadowing", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"audit": map[interface{}]interface{}{
+				"createdAt": "int64",
+			},
+			"base": map[interface{}]interface{}{
+				"id": "string",
+			},
+			"user": map[interface{}]interface{}{
+				"audit": nil,
+				"*base": nil,
+			},
+		}
+
+		warnings := lintYamlData(data)
+
+		assert.Equal(t, []error{newValidationWarningUnusedType("user")}, warnings)
+	})
+
+	t.Run("should consider types used in interfaces as used", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"user": map[interface{}]interface{}{
+				"name": "string",
+			},
+			"reader": interfaceDeclaration{
+				"Read": "func() user",
+			},
+			"store": interfaceDeclaration{
+				"reader": nil,
+			},
+		}
+
+		warnings := lintYamlData(data)
+
+		assert.Equal(t, []error{newValidationWarningUnusedType("store")}, warnings)
+	})
+
+	t.Run("should consider types of constants and variables as used", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": valueSection{
+				"timeout": map[interface{}]interface{}{"type": "duration", "value": 30},
+			},
+			"var": valueSection{
+				"players": "[]player",
+			},
+			"duration": "int64",
+			"player":   map[interface{}]interface{}{"name": "string"},
+		}
+
+		warnings := lintYamlData(data)
+
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("should consider types used as constraints as used", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"list", "[T stringer]"}: map[interface{}]interface{}{
+				"items": "[]T",
+			},
+			"stringer": interfaceDeclaration{
+				"String": "func() string",
+			},
+		}
+
+		warnings := lintYamlData(data)
+
+		assert.Equal(t, []error{newValidationWarningUnusedType("list")}, warnings)
+	})
+}
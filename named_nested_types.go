@@ -0,0 +1,72 @@
+package yamltostruct
+
+// replaces objects nested in objects by named types which are declared on root level;
+// the names are generated from the path of the nested object ("person.address" => "person_address").
+// The positions of the nested objects are copied so errors in the generated types can still be located.
+func hoistNestedObjects(yamlData map[interface{}]interface{}, positions declarationPositions) (map[interface{}]interface{}, []error) {
+	h := nestedObjectHoister{
+		yamlData:  yamlData,
+		positions: positions,
+		hoisted:   make(map[interface{}]interface{}),
+	}
+
+	rangeInAlphabeticalOrder(yamlData, func(keyName string, value interface{}) {
+		// nested objects of generic types may refer to the type parameters, so they stay anonymous
+		if key, _ := declarationKeyOf(yamlData, keyName); isGenericTypeName(key) {
+			h.hoisted[key] = value
+			return
+		}
+		if isMap(value) {
+			h.hoisted[keyName] = h.hoistObject(value.(map[interface{}]interface{}), keyName, keyName)
+			return
+		}
+		h.hoisted[keyName] = value
+	})
+
+	return h.hoisted, h.errs
+}
+
+type nestedObjectHoister struct {
+	yamlData  map[interface{}]interface{}
+	positions declarationPositions
+	hoisted   map[interface{}]interface{}
+	errs      []error
+}
+
+// returns a copy of the object in which nested objects are replaced by the names of the generated types;
+// objectName is the path of the object in the YAML document, typeName the name of the type it becomes
+func (h *nestedObjectHoister) hoistObject(yamlObjectData map[interface{}]interface{}, objectName, typeName string) map[interface{}]interface{} {
+	hoistedObjectData := make(map[interface{}]interface{})
+
+	rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+		if !isMap(value) {
+			hoistedObjectData[keyName] = value
+			h.copyPosition(declarationPathOf(keyName, typeName), declarationPathOf(keyName, objectName))
+			return
+		}
+
+		generatedTypeName := typeName + "_" + keyName
+		nestedObjectName := declarationPathOf(keyName, objectName)
+
+		// the name may be declared in the document or generated for another nested object
+		_, isDeclared := declarationOf(h.yamlData, generatedTypeName)
+		_, isGenerated := h.hoisted[generatedTypeName]
+		if isDeclared || isGenerated {
+			h.errs = append(h.errs, newValidationErrorNestedTypeNameConflict(generatedTypeName, keyName, objectName))
+			return
+		}
+
+		hoistedObjectData[keyName] = generatedTypeName
+		h.copyPosition(declarationPathOf(keyName, typeName), nestedObjectName)
+		h.copyPosition(generatedTypeName, nestedObjectName)
+		h.hoisted[generatedTypeName] = h.hoistObject(value.(map[interface{}]interface{}), nestedObjectName, generatedTypeName)
+	})
+
+	return hoistedObjectData
+}
+
+func (h *nestedObjectHoister) copyPosition(to, from string) {
+	if position, ok := h.positions[from]; ok {
+		h.positions[to] = position
+	}
+}
@@ -0,0 +1,122 @@
+package yamltostruct
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoistNestedObjects(t *testing.T) {
+	t.Run("should declare nested objects as named types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "string",
+			"bar": map[interface{}]interface{}{
+				"baz": "int",
+				"ban": map[interface{}]interface{}{
+					"bam": map[interface{}]interface{}{
+						"bal": "foo",
+					},
+				},
+			},
+		}
+
+		hoistedData, errs := hoistNestedObjects(data, make(declarationPositions))
+
+		assert.Empty(t, errs)
+		assert.Equal(t, map[interface{}]interface{}{
+			"foo": "string",
+			"bar": map[interface{}]interface{}{
+				"baz": "int",
+				"ban": "bar_ban",
+			},
+			"bar_ban": map[interface{}]interface{}{
+				"bam": "bar_ban_bam",
+			},
+			"bar_ban_bam": map[interface{}]interface{}{
+				"bal": "foo",
+			},
+		}, hoistedData)
+	})
+
+	t.Run("should copy positions of nested objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"bar": map[interface{}]interface{}{
+				"ban": map[interface{}]interface{}{
+					"bam": "int",
+				},
+			},
+		}
+		positions := declarationPositions{
+			"bar":         {key: token.Position{Line: 1, Column: 1}, value: token.Position{Line: 2, Column: 3}},
+			"bar.ban":     {key: token.Position{Line: 2, Column: 3}, value: token.Position{Line: 3, Column: 5}},
+			"bar.ban.bam": {key: token.Position{Line: 3, Column: 5}, value: token.Position{Line: 3, Column: 10}},
+		}
+
+		hoistNestedObjects(data, positions)
+
+		assert.Equal(t, positions["bar.ban"], positions["bar_ban"])
+		assert.Equal(t, positions["bar.ban.bam"], positions["bar_ban.bam"])
+	})
+
+	t.Run("should fail if generated type names are already declared", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"bar_ban": "string",
+			"bar": map[interface{}]interface{}{
+				"ban": map[interface{}]interface{}{
+					"bam": "int",
+				},
+				"ban_bal": map[interface{}]interface{}{
+					"bam": "int",
+				},
+				"bal": map[interface{}]interface{}{
+					"bam": "int",
+				},
+			},
+			"bar_ban_bal": map[interface{}]interface{}{},
+		}
+
+		_, errs := hoistNestedObjects(data, make(declarationPositions))
+
+		assert.Equal(t, []error{
+			newValidationErrorNestedTypeNameConflict("bar_ban", "ban", "bar"),
+			newValidationErrorNestedTypeNameConflict("bar_ban_bal", "ban_bal", "bar"),
+		}, errs)
+	})
+
+	t.Run("should fail if the same type name is generated twice", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": map[interface{}]interface{}{
+					"baz": map[interface{}]interface{}{
+						"ban": "int",
+					},
+				},
+				"bar_baz": map[interface{}]interface{}{
+					"ban": "int",
+				},
+			},
+		}
+
+		_, errs := hoistNestedObjects(data, make(declarationPositions))
+
+		assert.Equal(t, []error{
+			newValidationErrorNestedTypeNameConflict("foo_bar_baz", "bar_baz", "foo"),
+		}, errs)
+	})
+
+	t.Run("should keep nested objects of generic types anonymous", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"foo", "[T any]"}: map[interface{}]interface{}{
+				"bar": map[interface{}]interface{}{
+					"baz": "T",
+				},
+			},
+		}
+
+		hoistedData, errs := hoistNestedObjects(data, make(declarationPositions))
+
+		assert.Empty(t, errs)
+		assert.Equal(t, data, hoistedData)
+	})
+}
@@ -0,0 +1,32 @@
+package yamltostruct
+
+import "strings"
+
+// the suffix which marks keys of objects as optional fields ("nickname?: string")
+const optionalFieldSuffix = "?"
+
+// the value of an optional field; it holds the type which is generated for the field ("*string" for "nickname?: string"),
+// so optional fields are validated just like fields which are declared with this type
+type optionalFieldType string
+
+func isOptionalField(unknown interface{}) bool {
+	_, ok := unknown.(optionalFieldType)
+	return ok
+}
+
+// "nickname?" => ("nickname", true); keys which consist of the marker only are left to ErrIllegalTypeName
+func parseOptionalFieldName(keyName string) (string, bool) {
+	if len(keyName) <= len(optionalFieldSuffix) || !strings.HasSuffix(keyName, optionalFieldSuffix) {
+		return keyName, false
+	}
+	return strings.TrimSuffix(keyName, optionalFieldSuffix), true
+}
+
+// the type generated for an optional field; a pointer to the type or,
+// if a wrapper is configured, the wrapper instantiated with the type ("optional[string]")
+func optionalFieldTypeOf(typeString, wrapper string) optionalFieldType {
+	if wrapper == "" {
+		return optionalFieldType("*" + typeString)
+	}
+	return optionalFieldType(wrapper + "[" + typeString + "]")
+}
@@ -0,0 +1,35 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOptionalFieldName(t *testing.T) {
+	t.Run("should strip the marker of optional fields", func(t *testing.T) {
+		name, ok := parseOptionalFieldName("nickname?")
+		assert.True(t, ok)
+		assert.Equal(t, "nickname", name)
+	})
+
+	t.Run("should keep names without marker", func(t *testing.T) {
+		for _, keyName := range []string{"nickname", "?", "nick?name", ""} {
+			name, ok := parseOptionalFieldName(keyName)
+			assert.False(t, ok)
+			assert.Equal(t, keyName, name)
+		}
+	})
+}
+
+func TestOptionalFieldTypeOf(t *testing.T) {
+	t.Run("should generate pointers by default", func(t *testing.T) {
+		assert.Equal(t, optionalFieldType("*string"), optionalFieldTypeOf("string", ""))
+		assert.Equal(t, optionalFieldType("*[]int"), optionalFieldTypeOf("[]int", ""))
+	})
+
+	t.Run("should instantiate the wrapper", func(t *testing.T) {
+		assert.Equal(t, optionalFieldType("optional[string]"), optionalFieldTypeOf("string", "optional"))
+		assert.Equal(t, optionalFieldType("sql.Null[map[string]int]"), optionalFieldTypeOf("map[string]int", "sql.Null"))
+	})
+}
@@ -0,0 +1,144 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Order defines the order in which the declarations are generated
+type Order int
+
+const (
+	// AlphabeticalOrder sorts types, fields, methods, constants and variables by their names
+	AlphabeticalOrder Order = iota
+	// SourceOrder keeps the order in which types, fields, methods, constants and variables are declared in the YAML document
+	SourceOrder
+	// TopologicalOrder declares types after the types they depend on; types which do not depend on each other,
+	// fields, methods, constants and variables are sorted by their names
+	TopologicalOrder
+)
+
+// ranges over the keys of objects in the configured order
+type declarationOrder struct {
+	order     Order
+	positions declarationPositions
+	// the index of each root level type in topological order
+	topologicalIndices map[string]int
+}
+
+func newDeclarationOrder(order Order, yamlData map[interface{}]interface{}, positions declarationPositions) *declarationOrder {
+	o := declarationOrder{order: order, positions: positions}
+	if order == TopologicalOrder {
+		o.topologicalIndices = topologicalIndicesOf(yamlData)
+	}
+	return &o
+}
+
+// calls fn for every key of the object with the given path ("root" for the root level);
+// a nil declarationOrder ranges in alphabetical order
+func (o *declarationOrder) rangeKeys(data map[interface{}]interface{}, objectName string, fn func(keyName string, value interface{})) {
+	var keyNames []string
+	values := make(map[string]interface{})
+	rangeInAlphabeticalOrder(data, func(keyName string, value interface{}) {
+		keyNames = append(keyNames, keyName)
+		values[keyName] = value
+	})
+
+	if o != nil {
+		// stable, so keys which are not ordered otherwise stay in alphabetical order
+		sort.SliceStable(keyNames, func(i, j int) bool {
+			return o.less(declarationPathOf(keyNames[i], objectName), declarationPathOf(keyNames[j], objectName), objectName)
+		})
+	}
+
+	for _, keyName := range keyNames {
+		fn(keyName, values[keyName])
+	}
+}
+
+func (o *declarationOrder) less(path, otherPath, objectName string) bool {
+	switch {
+	case o.order == SourceOrder:
+		position, ok := o.positions[path]
+		otherPosition, otherOk := o.positions[otherPath]
+		// keys without position (e.g. generated ones) are declared last
+		if !ok || !otherOk {
+			return ok && !otherOk
+		}
+		if position.key.Line != otherPosition.key.Line {
+			return position.key.Line < otherPosition.key.Line
+		}
+		return position.key.Column < otherPosition.key.Column
+	case o.order == TopologicalOrder && objectName == "root":
+		index, ok := o.topologicalIndices[path]
+		otherIndex, otherOk := o.topologicalIndices[otherPath]
+		// keys which declare no types are declared last
+		if !ok || !otherOk {
+			return ok && !otherOk
+		}
+		return index < otherIndex
+	}
+	return false
+}
+
+// orders the types so each type follows the types it depends on; types are visited
+// in alphabetical order and cycles are broken where they are entered
+func topologicalIndicesOf(yamlData map[interface{}]interface{}) map[string]int {
+	indices := make(map[string]int)
+	visiting := make(map[string]bool)
+
+	var visit func(typeName string)
+	visit = func(typeName string) {
+		if _, ok := indices[typeName]; ok || visiting[typeName] {
+			return
+		}
+		visiting[typeName] = true
+		for _, dependency := range typeDependenciesOf(yamlData, typeName) {
+			visit(dependency)
+		}
+		indices[typeName] = len(indices)
+	}
+
+	rangeTypeDeclarations(yamlData, func(keyName string, _ interface{}) {
+		visit(keyName)
+	})
+
+	return indices
+}
+
+// the declared types the type refers to in its value, its fields, methods, embedded fields and constraints
+func typeDependenciesOf(yamlData map[interface{}]interface{}, typeName string) (dependencies []string) {
+	var usedTypes []string
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		if isInterface(value) {
+			value = map[interface{}]interface{}(value.(interfaceDeclaration))
+		}
+		if isMap(value) {
+			rangeInAlphabeticalOrder(value.(map[interface{}]interface{}), func(keyName string, item interface{}) {
+				// the key of an embedded field is the type it embeds
+				if isEmbeddedField(item) {
+					item = keyName
+				}
+				collect(item)
+			})
+			return
+		}
+		if isString(value) {
+			usedTypes = append(usedTypes, extractTypes(fmt.Sprintf("%v", value))...)
+		}
+	}
+
+	value, _ := declarationOf(yamlData, typeName)
+	collect(value)
+	for _, param := range typeParamsOf(yamlData, typeName) {
+		usedTypes = append(usedTypes, extractConstraintTypes(param.constraint)...)
+	}
+
+	for _, usedType := range usedTypes {
+		if _, ok := declarationOf(yamlData, usedType); ok && usedType != typeName {
+			dependencies = append(dependencies, usedType)
+		}
+	}
+	return
+}
@@ -0,0 +1,96 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rangedKeyNames(order *declarationOrder, data map[interface{}]interface{}, objectName string) (keyNames []string) {
+	order.rangeKeys(data, objectName, func(keyName string, _ interface{}) {
+		keyNames = append(keyNames, keyName)
+	})
+	return
+}
+
+func TestDeclarationOrder(t *testing.T) {
+	yamlDataBytes := []byte(
+		`user:
+  id: string
+  name: string
+  created_at: int64
+  address: address
+address:
+  street: street
+street: string
+const:
+  maxUsers: 10
+  maxAge: 150`,
+	)
+	yamlData, positions, err := convertToDataMap(yamlDataBytes, "", "")
+	assert.Nil(t, err)
+
+	t.Run("should range in alphabetical order by default", func(t *testing.T) {
+		order := newDeclarationOrder(AlphabeticalOrder, yamlData, positions)
+
+		assert.Equal(t, []string{"address", "const", "street", "user"}, rangedKeyNames(order, yamlData, "root"))
+		assert.Equal(t, []string{"address", "created_at", "id", "name"}, rangedKeyNames(order, yamlData["user"].(map[interface{}]interface{}), "user"))
+	})
+
+	t.Run("should range in alphabetical order without declarationOrder", func(t *testing.T) {
+		var order *declarationOrder
+
+		assert.Equal(t, []string{"address", "created_at", "id", "name"}, rangedKeyNames(order, yamlData["user"].(map[interface{}]interface{}), "user"))
+	})
+
+	t.Run("should range in source order", func(t *testing.T) {
+		order := newDeclarationOrder(SourceOrder, yamlData, positions)
+
+		assert.Equal(t, []string{"user", "address", "street", "const"}, rangedKeyNames(order, yamlData, "root"))
+		assert.Equal(t, []string{"id", "name", "created_at", "address"}, rangedKeyNames(order, yamlData["user"].(map[interface{}]interface{}), "user"))
+		assert.Equal(t, []string{"maxUsers", "maxAge"}, rangedKeyNames(order, yamlData["const"].(valueSection), "const"))
+	})
+
+	t.Run("should range keys without position last in source order", func(t *testing.T) {
+		order := newDeclarationOrder(SourceOrder, yamlData, positions)
+		data := map[interface{}]interface{}{"b": "int", "generated": "int", "a": "int", "user": "int", "address": "int"}
+
+		assert.Equal(t, []string{"user", "address", "a", "b", "generated"}, rangedKeyNames(order, data, "root"))
+	})
+
+	t.Run("should range types in topological order", func(t *testing.T) {
+		order := newDeclarationOrder(TopologicalOrder, yamlData, positions)
+
+		assert.Equal(t, []string{"street", "address", "user", "const"}, rangedKeyNames(order, yamlData, "root"))
+		assert.Equal(t, []string{"address", "created_at", "id", "name"}, rangedKeyNames(order, yamlData["user"].(map[interface{}]interface{}), "user"))
+	})
+}
+
+func TestTopologicalIndicesOf(t *testing.T) {
+	t.Run("should order types after their dependencies", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"a":                           "[]c",
+			"b":                           map[interface{}]interface{}{"d": nil, "e": "map[string]e"},
+			"c":                           "int",
+			"d":                           interfaceDeclaration{"F": "func() e"},
+			"e":                           "string",
+			genericTypeName{"f", "[T g]"}: "[]T",
+			"g":                           "string",
+		}
+
+		indices := topologicalIndicesOf(data)
+
+		assert.Equal(t, map[string]int{"c": 0, "a": 1, "e": 2, "d": 3, "b": 4, "g": 5, "f": 6}, indices)
+	})
+
+	t.Run("should break cycles where they are entered", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"a": map[interface{}]interface{}{"next": "*b"},
+			"b": map[interface{}]interface{}{"next": "*a", "self": "*b"},
+		}
+
+		indices := topologicalIndicesOf(data)
+
+		assert.Equal(t, map[string]int{"b": 0, "a": 1}, indices)
+	})
+}
@@ -0,0 +1,195 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy defines how field names are converted into the names used in struct tags
+type NamingStrategy int
+
+const (
+	// OriginalName keeps the field name as it is declared ("firstName" => "firstName")
+	OriginalName NamingStrategy = iota
+	// SnakeCase converts field names to snake_case ("firstName" => "first_name")
+	SnakeCase
+	// CamelCase converts field names to camelCase ("first_name" => "firstName")
+	CamelCase
+	// KebabCase converts field names to kebab-case ("firstName" => "first-name")
+	KebabCase
+)
+
+// StructTag describes a key which is added to the struct tags of all fields
+type StructTag struct {
+	// the key of the tag, e.g. "json", "yaml", "bson" or "db"
+	Key    string
+	Naming NamingStrategy
+	// appends ",omitempty" to the names
+	OmitEmpty bool
+}
+
+type structTagger struct {
+	tags []StructTag
+	// the tag values set for single fields, keyed by the path of the field and the tag key
+	overrides map[string]map[string]string
+}
+
+func newStructTagger(tags []StructTag, overrides map[string]map[string]string) *structTagger {
+	return &structTagger{tags: tags, overrides: overrides}
+}
+
+// returns an error if a tag key could not be written into a struct tag;
+// https://golang.org/pkg/reflect/#StructTag
+func (t *structTagger) validate() error {
+	isValidKey := func(key string) bool {
+		if key == "" {
+			return false
+		}
+		for _, ch := range key {
+			if ch <= ' ' || ch == ':' || ch == '"' || ch == '`' || ch == 0x7f {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, tag := range t.tags {
+		if !isValidKey(tag.Key) {
+			return fmt.Errorf("yamltostruct: invalid struct tag key %q", tag.Key)
+		}
+	}
+	for fieldPath, overrides := range t.overrides {
+		for key := range overrides {
+			if !isValidKey(key) {
+				return fmt.Errorf("yamltostruct: invalid struct tag key %q for field %q", key, fieldPath)
+			}
+		}
+	}
+	return nil
+}
+
+// returns the struct tag literal of the field, or an empty string if it has no tags;
+// fieldPath is the path of the field ("person.name"); optional fields are always omitted if empty
+func (t *structTagger) tagOf(fieldPath, fieldName string, isOptional bool) string {
+	if t == nil {
+		return ""
+	}
+
+	overrides := t.overrides[fieldPath]
+
+	var keyValuePairs []string
+	addKeyValuePair := func(key, value string) {
+		keyValuePairs = append(keyValuePairs, key+":"+strconv.Quote(value))
+	}
+
+	for _, tag := range t.tags {
+		if value, ok := overrides[tag.Key]; ok {
+			addKeyValuePair(tag.Key, value)
+			continue
+		}
+		value := convertName(fieldName, tag.Naming)
+		if tag.OmitEmpty || isOptional {
+			value += ",omitempty"
+		}
+		addKeyValuePair(tag.Key, value)
+	}
+
+	// keys which are only set for this field follow in alphabetical order
+	var overrideOnlyKeys []string
+	for key := range overrides {
+		if !t.isConfiguredKey(key) {
+			overrideOnlyKeys = append(overrideOnlyKeys, key)
+		}
+	}
+	sort.Strings(overrideOnlyKeys)
+	for _, key := range overrideOnlyKeys {
+		addKeyValuePair(key, overrides[key])
+	}
+
+	if len(keyValuePairs) == 0 {
+		return ""
+	}
+
+	tag := strings.Join(keyValuePairs, " ")
+	// raw string literals cannot contain backticks
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+func (t *structTagger) isConfiguredKey(key string) bool {
+	for _, tag := range t.tags {
+		if tag.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func convertName(name string, naming NamingStrategy) string {
+	words := splitWords(name)
+
+	switch naming {
+	case SnakeCase:
+		return strings.ToLower(strings.Join(words, "_"))
+	case KebabCase:
+		return strings.ToLower(strings.Join(words, "-"))
+	case CamelCase:
+		var b strings.Builder
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i > 0 {
+				runes := []rune(word)
+				runes[0] = unicode.ToUpper(runes[0])
+				word = string(runes)
+			}
+			b.WriteString(word)
+		}
+		return b.String()
+	}
+
+	return name
+}
+
+// splits a name into its words at underscores, hyphens and changes of case;
+// "firstName" => []string{"first", "Name"}, "HTTPServer_url" => []string{"HTTP", "Server", "url"}
+func splitWords(name string) (words []string) {
+	runes := []rune(name)
+
+	var word []rune
+	addWord := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+		}
+		word = nil
+	}
+
+	for i, ch := range runes {
+		if ch == '_' || ch == '-' {
+			addWord()
+			continue
+		}
+
+		if unicode.IsUpper(ch) && i > 0 {
+			previous := runes[i-1]
+			// "firstName" => "first", "Name"
+			startsWord := unicode.IsLower(previous) || unicode.IsDigit(previous)
+			// "HTTPServer" => "HTTP", "Server"
+			if unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				startsWord = true
+			}
+			if startsWord {
+				addWord()
+			}
+		}
+
+		word = append(word, ch)
+	}
+	addWord()
+
+	return
+}
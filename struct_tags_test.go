@@ -0,0 +1,91 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitWords(t *testing.T) {
+	t.Run("should split names into words", func(t *testing.T) {
+		assert.Equal(t, []string{"name"}, splitWords("name"))
+		assert.Equal(t, []string{"first", "Name"}, splitWords("firstName"))
+		assert.Equal(t, []string{"First", "Name"}, splitWords("FirstName"))
+		assert.Equal(t, []string{"first", "name"}, splitWords("first_name"))
+		assert.Equal(t, []string{"first", "name"}, splitWords("first__name_"))
+		assert.Equal(t, []string{"user", "ID"}, splitWords("userID"))
+		assert.Equal(t, []string{"HTTP", "Server"}, splitWords("HTTPServer"))
+		assert.Equal(t, []string{"address2", "Street"}, splitWords("address2Street"))
+		assert.Equal(t, []string{"ID"}, splitWords("ID"))
+	})
+}
+
+func TestConvertName(t *testing.T) {
+	t.Run("should convert names according to naming strategy", func(t *testing.T) {
+		assert.Equal(t, "firstName", convertName("firstName", OriginalName))
+		assert.Equal(t, "first_name", convertName("firstName", SnakeCase))
+		assert.Equal(t, "user_id", convertName("userID", SnakeCase))
+		assert.Equal(t, "http_server", convertName("HTTPServer", SnakeCase))
+		assert.Equal(t, "first-name", convertName("first_name", KebabCase))
+		assert.Equal(t, "firstName", convertName("first_name", CamelCase))
+		assert.Equal(t, "firstName", convertName("FirstName", CamelCase))
+		assert.Equal(t, "userId", convertName("userID", CamelCase))
+		assert.Equal(t, "httpServer", convertName("HTTPServer", CamelCase))
+	})
+}
+
+func TestStructTagger(t *testing.T) {
+	t.Run("should not tag fields without tag configuration", func(t *testing.T) {
+		var tagger *structTagger
+		assert.Equal(t, "", tagger.tagOf("person.firstName", "firstName", false))
+		assert.Equal(t, "", newStructTagger(nil, nil).tagOf("person.firstName", "firstName", false))
+	})
+
+	t.Run("should tag fields with all configured keys", func(t *testing.T) {
+		tagger := newStructTagger([]StructTag{
+			{Key: "json", Naming: CamelCase, OmitEmpty: true},
+			{Key: "db", Naming: SnakeCase},
+		}, nil)
+
+		assert.Equal(t, "`json:\"firstName,omitempty\" db:\"first_name\"`", tagger.tagOf("person.first_name", "first_name", false))
+	})
+
+	t.Run("should prefer tags set for single fields", func(t *testing.T) {
+		tagger := newStructTagger(
+			[]StructTag{{Key: "json", Naming: SnakeCase}, {Key: "yaml", Naming: KebabCase}},
+			map[string]map[string]string{
+				"person.password":   {"json": "-"},
+				"person.firstName":  {"yaml": "first,omitempty", "xml": "first", "bson": "first"},
+				"person.otherField": {"json": "other"},
+			},
+		)
+
+		assert.Equal(t, "`json:\"-\" yaml:\"password\"`", tagger.tagOf("person.password", "password", false))
+		assert.Equal(t, "`json:\"first_name\" yaml:\"first,omitempty\" bson:\"first\" xml:\"first\"`", tagger.tagOf("person.firstName", "firstName", false))
+	})
+
+	t.Run("should quote tags containing backticks", func(t *testing.T) {
+		tagger := newStructTagger(nil, map[string]map[string]string{
+			"person.name": {"json": "na`me"},
+		})
+
+		assert.Equal(t, "\"json:\\\"na`me\\\"\"", tagger.tagOf("person.name", "name", false))
+	})
+
+	t.Run("should reject invalid tag keys", func(t *testing.T) {
+		assert.NoError(t, newStructTagger([]StructTag{{Key: "json"}}, map[string]map[string]string{"a.b": {"db": "b"}}).validate())
+		assert.Error(t, newStructTagger([]StructTag{{Key: ""}}, nil).validate())
+		assert.Error(t, newStructTagger([]StructTag{{Key: "js on"}}, nil).validate())
+		assert.Error(t, newStructTagger([]StructTag{{Key: "json:\""}}, nil).validate())
+		assert.Error(t, newStructTagger(nil, map[string]map[string]string{"a.b": {"d`b": "b"}}).validate())
+	})
+
+	t.Run("should omit empty optional fields", func(t *testing.T) {
+		tagger := newStructTagger(
+			[]StructTag{{Key: "json", Naming: SnakeCase}},
+			map[string]map[string]string{"person.nickName": {"yaml": "nick"}},
+		)
+
+		assert.Equal(t, "`json:\"nick_name,omitempty\" yaml:\"nick\"`", tagger.tagOf("person.nickName", "nickName", true))
+	})
+}
@@ -0,0 +1,305 @@
+package yamltostruct
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+)
+
+// value strings are spliced into the generated source code verbatim, so a comment
+// would swallow whatever follows it on the line ("int // note" followed by the struct tag)
+var errContainsComment = errors.New("comments are not allowed")
+
+// whether the source contains a comment ("int // note", "func(/* p */ int)")
+func containsComment(source string) bool {
+	fileSet := token.NewFileSet()
+	file := fileSet.AddFile("", fileSet.Base(), len(source))
+	var s scanner.Scanner
+	s.Init(file, []byte(source), nil, scanner.ScanComments)
+	for {
+		_, tok, _ := s.Scan()
+		switch tok {
+		case token.COMMENT:
+			return true
+		case token.EOF:
+			return false
+		}
+	}
+}
+
+// the source code of the node as written in source, which was parsed on its own by
+// parser.ParseExpr or parser.ParseFile; positions of such nodes start at 1
+func exprSource(source string, node ast.Node) string {
+	return source[node.Pos()-1 : node.End()-1]
+}
+
+// parses a value string which has to consist of exactly one type expression;
+// anything following the expression ("int; func init() {}", "int // note") or expressions
+// which are no types ("1+2", "func() {}") result in an error
+func parseTypeExpr(valueString string) (ast.Expr, error) {
+	// parser.ParseExpr fails if anything but comments follows the expression
+	if containsComment(valueString) {
+		return nil, errContainsComment
+	}
+	expr, err := parser.ParseExpr(valueString)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isTypeExpr(expr) {
+		return nil, errors.New("not a type expression")
+	}
+
+	return expr, nil
+}
+
+// parses the value of a constant or variable, which has to consist of exactly one expression;
+// function literals are rejected as their bodies could contain any code ("func() int { ... }()")
+func parseValueExpr(valueString string) (ast.Expr, error) {
+	if containsComment(valueString) {
+		return nil, errContainsComment
+	}
+	expr, err := parser.ParseExpr(valueString)
+	if err != nil {
+		return nil, err
+	}
+
+	var containsFuncLit bool
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			containsFuncLit = true
+		}
+		return !containsFuncLit
+	})
+	if containsFuncLit {
+		return nil, errors.New("function literals are not allowed")
+	}
+
+	return expr, nil
+}
+
+func isTypeExpr(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		// qualified identifiers ("foo.Bar")
+		_, ok := e.X.(*ast.Ident)
+		return ok
+	case *ast.ParenExpr:
+		return isTypeExpr(e.X)
+	case *ast.StarExpr:
+		return isTypeExpr(e.X)
+	// instantiations of generic types ("list[user]", "pair[string, int]")
+	case *ast.IndexExpr:
+		return isTypeName(e.X) && isTypeExpr(e.Index)
+	case *ast.IndexListExpr:
+		for _, index := range e.Indices {
+			if !isTypeExpr(index) {
+				return false
+			}
+		}
+		return isTypeName(e.X)
+	case *ast.ArrayType:
+		if e.Len != nil && !isConstantExpr(e.Len) {
+			return false
+		}
+		return isTypeExpr(e.Elt)
+	case *ast.MapType:
+		return isTypeExpr(e.Key) && isTypeExpr(e.Value)
+	case *ast.ChanType:
+		return isTypeExpr(e.Value)
+	case *ast.FuncType:
+		return e.TypeParams == nil && isFieldListOfTypes(e.Params, true) && isFieldListOfTypes(e.Results, false)
+	case *ast.StructType:
+		return isFieldListOfTypes(e.Fields, false)
+	case *ast.InterfaceType:
+		return isFieldListOfTypes(e.Methods, false)
+	}
+	return false
+}
+
+// (qualified) identifiers ("foo", "foo.Bar")
+func isTypeName(expr ast.Expr) bool {
+	_, ok := typeNameOf(expr)
+	return ok
+}
+
+func typeNameOf(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name, true
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok {
+			return pkg.Name + "." + e.Sel.Name, true
+		}
+	}
+	return "", false
+}
+
+// constraints of type parameters are type expressions or unions
+// of them, whose terms may be approximations ("~int | ~string")
+func isConstraintExpr(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		return e.Op == token.OR && isConstraintExpr(e.X) && isConstraintExpr(e.Y)
+	case *ast.UnaryExpr:
+		return e.Op == token.TILDE && isTypeExpr(e.X)
+	}
+	return isTypeExpr(expr)
+}
+
+// the terms of a union constraint; "~int | string" => [~int string]
+func constraintTermsOf(expr ast.Expr) []ast.Expr {
+	if union, ok := expr.(*ast.BinaryExpr); ok && union.Op == token.OR {
+		return append(constraintTermsOf(union.X), constraintTermsOf(union.Y)...)
+	}
+	return []ast.Expr{expr}
+}
+
+func isFieldListOfTypes(fieldList *ast.FieldList, allowVariadic bool) bool {
+	if fieldList == nil {
+		return true
+	}
+	for i, field := range fieldList.List {
+		// "...int" is only valid as last parameter
+		if ellipsis, ok := field.Type.(*ast.Ellipsis); ok {
+			if !allowVariadic || i != len(fieldList.List)-1 || !isTypeExpr(ellipsis.Elt) {
+				return false
+			}
+			continue
+		}
+		if !isTypeExpr(field.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// array lengths are restricted to literals, (qualified) identifiers and operations on them
+func isConstantExpr(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return e.Kind == token.INT
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		_, ok := e.X.(*ast.Ident)
+		return ok
+	case *ast.ParenExpr:
+		return isConstantExpr(e.X)
+	case *ast.UnaryExpr:
+		return isConstantExpr(e.X)
+	case *ast.BinaryExpr:
+		return isConstantExpr(e.X) && isConstantExpr(e.Y)
+	}
+	return false
+}
+
+// calls fn for every identifier a type expression refers to, in order of appearance;
+// qualified identifiers are passed as a whole ("time.Time") and identifiers used
+// as array lengths are flagged, field, parameter and method names are skipped
+// map[foo][n]bar => ("foo", false), ("n", true), ("bar", false)
+func walkTypeReferences(expr ast.Expr, fn func(name string, isArrayLength bool)) {
+	var walk func(node ast.Node, isArrayLength bool)
+	walk = func(node ast.Node, isArrayLength bool) {
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch e := n.(type) {
+			case *ast.Ident:
+				fn(e.Name, isArrayLength)
+				return false
+			case *ast.SelectorExpr:
+				if pkg, ok := e.X.(*ast.Ident); ok {
+					fn(pkg.Name+"."+e.Sel.Name, isArrayLength)
+				}
+				return false
+			case *ast.ArrayType:
+				if e.Len != nil {
+					walk(e.Len, true)
+				}
+				walk(e.Elt, isArrayLength)
+				return false
+			case *ast.Field:
+				// only the type of fields, parameters and methods is of interest
+				walk(e.Type, isArrayLength)
+				return false
+			}
+			return true
+		})
+	}
+
+	walk(expr, false)
+}
+
+// calls fn for every (qualified) type name a type expression refers to, together with the
+// type arguments it is instantiated with; names which are not instantiated are passed
+// without type arguments, array lengths and field, parameter and method names are skipped
+// map[pair[a, b]]c => ("pair", [a b]), ("a", nil), ("b", nil), ("c", nil)
+func walkTypeInstantiations(expr ast.Expr, fn func(name string, typeArgs []ast.Expr)) {
+	var walk func(node ast.Node)
+	walk = func(node ast.Node) {
+		ast.Inspect(node, func(n ast.Node) bool {
+			var genericType ast.Expr
+			var typeArgs []ast.Expr
+			switch e := n.(type) {
+			case *ast.Ident, *ast.SelectorExpr:
+				if name, ok := typeNameOf(e.(ast.Expr)); ok {
+					fn(name, nil)
+				}
+				return false
+			case *ast.IndexExpr:
+				genericType, typeArgs = e.X, []ast.Expr{e.Index}
+			case *ast.IndexListExpr:
+				genericType, typeArgs = e.X, e.Indices
+			case *ast.ArrayType:
+				walk(e.Elt)
+				return false
+			case *ast.Field:
+				walk(e.Type)
+				return false
+			default:
+				return true
+			}
+
+			if name, ok := typeNameOf(genericType); ok {
+				fn(name, typeArgs)
+			}
+			for _, typeArg := range typeArgs {
+				walk(typeArg)
+			}
+			return false
+		})
+	}
+
+	walk(expr)
+}
+
+// returns the names of the types whose values are contained in values of the type expression;
+// slices, pointers, maps, funcs, chans and interfaces only refer to values of other types
+// "[23]foo" => []string{"foo"}, "struct{ a foo; b *bar }" => []string{"foo"}, "func() foo" => nil
+func typeNamesContainedByValue(expr ast.Expr) (typeNames []string) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return []string{e.Name}
+	case *ast.ParenExpr:
+		return typeNamesContainedByValue(e.X)
+	// whether the type arguments are contained depends on the generic type, which is left to the type check
+	case *ast.IndexExpr:
+		return typeNamesContainedByValue(e.X)
+	case *ast.IndexListExpr:
+		return typeNamesContainedByValue(e.X)
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return nil
+		}
+		return typeNamesContainedByValue(e.Elt)
+	case *ast.StructType:
+		for _, field := range e.Fields.List {
+			typeNames = append(typeNames, typeNamesContainedByValue(field.Type)...)
+		}
+		return typeNames
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"go/ast"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTypeExpr(t *testing.T) {
+	t.Run("should parse type expressions", func(t *testing.T) {
+		for _, valueString := range []string{
+			"foo",
+			"time.Time",
+			"*[]map[foo]bar",
+			"[2]foo",
+			"[2*3]foo",
+			"(foo)",
+			"chan<- foo",
+			"func(foo, ...bar) (int, error)",
+			"struct{ foo int; bar string }",
+			"interface{ foo() int }",
+			"list[foo]",
+			"pair[foo, *bar]",
+			"time.Box[map[foo]bar]",
+		} {
+			_, err := parseTypeExpr(valueString)
+			assert.NoError(t, err, valueString)
+		}
+	})
+
+	t.Run("should reject anything but a single type expression", func(t *testing.T) {
+		for _, valueString := range []string{
+			"",
+			"foo bar",
+			"foo; func init() {}",
+			"foo\ntype evil struct{}",
+			"foo()",
+			"foo{}",
+			"func() {}",
+			"\"foo\"",
+			"a.b.c",
+			"[foo()]int",
+			"func(...foo, bar)",
+			"foo[2]",
+			"foo()[bar]",
+			"[]foo[bar]{}",
+			"int // note",
+			"int /* note */",
+			"func(/* p */ int)",
+		} {
+			_, err := parseTypeExpr(valueString)
+			assert.Error(t, err, valueString)
+		}
+	})
+}
+
+func TestExprSource(t *testing.T) {
+	t.Run("should return the source of nodes as written", func(t *testing.T) {
+		valueString := "map[ [2]map[string]int ]string"
+		typeExpr, err := parseTypeExpr(valueString)
+		assert.NoError(t, err)
+
+		mapType := typeExpr.(*ast.MapType)
+		assert.Equal(t, "[2]map[string]int", exprSource(valueString, mapType.Key))
+	})
+
+	t.Run("should return the parameters and results of func types", func(t *testing.T) {
+		valueString := "func(p []byte)  (int, error)"
+		typeExpr, err := parseTypeExpr(valueString)
+		assert.NoError(t, err)
+
+		funcType := typeExpr.(*ast.FuncType)
+		assert.Equal(t, "(p []byte)", exprSource(valueString, funcType.Params))
+		assert.Equal(t, "(int, error)", exprSource(valueString, funcType.Results))
+	})
+}
+
+func TestTypeNamesContainedByValue(t *testing.T) {
+	t.Run("should return the types contained by value", func(t *testing.T) {
+		for valueString, expectedTypeNames := range map[string][]string{
+			"foo":                              {"foo"},
+			"(foo)":                            {"foo"},
+			"[2][3]foo":                        {"foo"},
+			"struct{ a foo; b, c bar }":        {"foo", "bar"},
+			"[2]struct{ a foo; b *bar }":       {"foo"},
+			"struct{ a struct{ b foo } }":      {"foo"},
+			"*foo":                             nil,
+			"[]foo":                            nil,
+			"map[foo]bar":                      nil,
+			"func(foo) bar":                    nil,
+			"func(...foo) (bar, error)":        nil,
+			"chan foo":                         nil,
+			"chan<- foo":                       nil,
+			"<-chan foo":                       nil,
+			"interface{ foo() bar }":           nil,
+			"[2]func() foo":                    nil,
+			"struct{ a chan foo; b func() }":   nil,
+			"struct{ a map[foo]bar; b []foo }": nil,
+			"list[foo]":                        {"list"},
+			"pair[foo, bar]":                   {"pair"},
+		} {
+			typeExpr, err := parseTypeExpr(valueString)
+			assert.NoError(t, err, valueString)
+			assert.Equal(t, expectedTypeNames, typeNamesContainedByValue(typeExpr), valueString)
+		}
+	})
+}
+
+func TestWalkTypeInstantiations(t *testing.T) {
+	t.Run("should pass type names with the type arguments they are instantiated with", func(t *testing.T) {
+		typeExpr, err := parseTypeExpr("map[pair[a, list[b]]][n]func(x c) time.Box[d]")
+		assert.NoError(t, err)
+
+		var instantiations []string
+		walkTypeInstantiations(typeExpr, func(name string, typeArgs []ast.Expr) {
+			instantiations = append(instantiations, fmt.Sprintf("%s%d", name, len(typeArgs)))
+		})
+
+		assert.Equal(t, []string{"pair2", "a0", "list1", "b0", "c0", "time.Box1", "d0"}, instantiations)
+	})
+}
+
+func TestParseValueExpr(t *testing.T) {
+	t.Run("should parse value expressions", func(t *testing.T) {
+		for _, valueString := range []string{
+			"4",
+			"maxPlayers / 2",
+			`"hello"`,
+			"int64(30)",
+			"[]int{1, 2}",
+			`"https://example.com"`,
+		} {
+			_, err := parseValueExpr(valueString)
+			assert.NoError(t, err, valueString)
+		}
+	})
+
+	t.Run("should reject anything but a single expression without function literals", func(t *testing.T) {
+		for _, valueString := range []string{
+			"",
+			"1; func init() {}",
+			"1 2",
+			"func() int { return 1 }()",
+			"[]func(){func() {}}",
+			"4 // players",
+		} {
+			_, err := parseValueExpr(valueString)
+			assert.Error(t, err, valueString)
+		}
+	})
+}
@@ -1,34 +1,461 @@
 package yamltostruct
 
 import (
+	"errors"
+	"fmt"
 	"go/ast"
+	"go/token"
+	"strings"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 )
 
-func convertToDataMap(yamlDataBytes []byte) (map[interface{}]interface{}, error) {
+// Option configures the behaviour of Unmarshal
+type Option func(*config)
+
+type config struct {
+	fileName             string
+	exhaustiveValidation bool
+	namedNestedTypes     bool
+	structTags           []StructTag
+	// tag values of single fields, keyed by field path and tag key
+	fieldTags        map[string]map[string]string
+	promotedWarnings []error
+	// the file set the declarations are parsed into if comments are carried over; nil otherwise
+	commentFileSet *token.FileSet
+	order          Order
+	// the generic type optional fields are wrapped in; optional fields are pointers if it is empty
+	optionalFieldWrapper string
+}
+
+// WithFileName sets the file name that is reported in the positions of validation errors
+func WithFileName(fileName string) Option {
+	return func(c *config) {
+		c.fileName = fileName
+	}
+}
+
+// WithExhaustiveValidation makes Unmarshal run all validation phases and report all errors at once.
+// By default validation stops after the first phase that reported errors.
+// In exhaustive mode declarations that failed a phase are skipped in the following phases.
+func WithExhaustiveValidation() Option {
+	return func(c *config) {
+		c.exhaustiveValidation = true
+	}
+}
+
+// WithNamedNestedTypes makes objects nested in objects become named types instead of anonymous structs.
+// The names are generated from the path of the nested object ("person.address" => "person_address").
+func WithNamedNestedTypes() Option {
+	return func(c *config) {
+		c.namedNestedTypes = true
+	}
+}
+
+// WithStructTags adds struct tags with the given keys to all fields,
+// e.g. WithStructTags(StructTag{Key: "json", Naming: SnakeCase, OmitEmpty: true})
+func WithStructTags(tags ...StructTag) Option {
+	return func(c *config) {
+		c.structTags = append(c.structTags, tags...)
+	}
+}
+
+// WithFieldTag sets the value of a tag key for a single field, e.g.
+// WithFieldTag("person.firstName", "json", "first,omitempty") or WithFieldTag("person.password", "json", "-").
+// The field is given by its path; fields of nested objects are named
+// like "person.address.street" ("person_address.street" with WithNamedNestedTypes).
+func WithFieldTag(fieldPath, key, value string) Option {
+	return func(c *config) {
+		if c.fieldTags == nil {
+			c.fieldTags = make(map[string]map[string]string)
+		}
+		if c.fieldTags[fieldPath] == nil {
+			c.fieldTags[fieldPath] = make(map[string]string)
+		}
+		c.fieldTags[fieldPath][key] = value
+	}
+}
+
+// WithWarningsAsErrors makes the given warning kinds (e.g. WarnUnusedType) count as errors,
+// so they are returned as errors and prevent the generation of declarations
+func WithWarningsAsErrors(warningKinds ...error) Option {
+	return func(c *config) {
+		c.promotedWarnings = append(c.promotedWarnings, warningKinds...)
+	}
+}
+
+// WithComments carries the comments of the YAML document over into the declarations: comments above
+// a root level key become the doc comment of its type, comments above or beside fields, methods,
+// enum values, constants and variables become their doc and line comments.
+// The declarations are parsed into fileSet, which has to be passed to go/printer so it places the comments correctly.
+func WithComments(fileSet *token.FileSet) Option {
+	return func(c *config) {
+		c.commentFileSet = fileSet
+	}
+}
+
+// WithOrder sets the order in which the declarations are generated (AlphabeticalOrder by default).
+// SourceOrder keeps the order of the YAML document, e.g. to group fields or to control the memory layout of structs.
+func WithOrder(order Order) Option {
+	return func(c *config) {
+		c.order = order
+	}
+}
+
+// WithOptionalFieldWrapper makes optional fields ("nickname?: string") use the given generic type
+// instead of a pointer, e.g. WithOptionalFieldWrapper("optional") generates "nickname optional[string]".
+// The wrapper has to be declared in the YAML document or in the types of an imported package.
+func WithOptionalFieldWrapper(wrapper string) Option {
+	return func(c *config) {
+		c.optionalFieldWrapper = wrapper
+	}
+}
+
+func (c *config) isPromotedWarning(warning error) bool {
+	for _, warningKind := range c.promotedWarnings {
+		if errors.Is(warning, warningKind) {
+			return true
+		}
+	}
+	return false
+}
+
+// where a key and its value were declared in the YAML source and the comments written around them
+type declarationPosition struct {
+	key   token.Position
+	value token.Position
+	// the comment lines above the key and the comment beside it, as written in the YAML source ("# ...")
+	headComment string
+	lineComment string
+}
+
+// positions of all declarations, keyed by their path ("foo" for root level keys, "foo.bar" for fields)
+type declarationPositions map[string]declarationPosition
+
+func declarationPathOf(keyName, parentItemName string) string {
+	if parentItemName == "root" {
+		return keyName
+	}
+	return parentItemName + "." + keyName
+}
+
+// the reverse of declarationPathOf; "foo.bar" => ("bar", "foo"), "foo" => ("foo", "root")
+func splitDeclarationPath(path string) (keyName, parentItemName string) {
+	i := strings.LastIndex(path, ".")
+	if i == -1 {
+		return path, "root"
+	}
+	return path[i+1:], path[:i]
+}
+
+func nodePosition(node *yaml.Node, fileName string) token.Position {
+	return token.Position{Filename: fileName, Line: node.Line, Column: node.Column}
+}
+
+// we decode into yaml.Node trees instead of maps directly so the
+// source positions of all keys and values are not lost
+func convertToDataMap(yamlDataBytes []byte, fileName, optionalFieldWrapper string) (map[interface{}]interface{}, declarationPositions, error) {
 	yamlData := make(map[interface{}]interface{})
-	err := yaml.Unmarshal(yamlDataBytes, &yamlData)
+	positions := make(declarationPositions)
 
+	var document yaml.Node
+	err := yaml.Unmarshal(yamlDataBytes, &document)
 	if err != nil {
-		return yamlData, err
+		return yamlData, positions, err
+	}
+
+	// an empty document
+	if len(document.Content) == 0 {
+		return yamlData, positions, nil
+	}
+
+	rootNode := resolveAlias(document.Content[0])
+	if rootNode.Kind != yaml.MappingNode {
+		return yamlData, positions, errors.New("yaml: document root has to be an object")
 	}
 
-	return yamlData, err
+	c := nodeConverter{
+		fileName:             fileName,
+		positions:            positions,
+		optionalFieldWrapper: optionalFieldWrapper,
+		lines:                strings.Split(string(yamlDataBytes), "\n"),
+	}
+	err = c.convertMapping(rootNode, "", yamlData, false)
+
+	return yamlData, positions, err
 }
 
-func Unmarshal(yamlDataBytes []byte) ([]ast.Decl, []error) {
-	yamlData, err := convertToDataMap(yamlDataBytes)
+type nodeConverter struct {
+	fileName             string
+	positions            declarationPositions
+	optionalFieldWrapper string
+	// the lines of the YAML document
+	lines []string
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// the tag which marks objects as interface declarations
+const interfaceTag = "!interface"
+
+// the prefix which marks values of named types as aliases
+const aliasPrefix = "="
+
+func (c *nodeConverter) convert(node *yaml.Node, path string) (interface{}, error) {
+	node = resolveAlias(node)
+
+	if node.Tag == interfaceTag {
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("yaml: line %d: only objects can be tagged with %s", node.Line, interfaceTag)
+		}
+		interfaceValue := make(interfaceDeclaration)
+		err := c.convertMapping(node, path, interfaceValue, false)
+		return interfaceValue, err
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		mapValue := make(map[interface{}]interface{})
+		// only objects declaring types have fields, the sections of the root level do not
+		isObject := path != "" && !isReservedKey(strings.Split(path, ".")[0])
+		err := c.convertMapping(node, path, mapValue, isObject)
+		return mapValue, err
+	case yaml.SequenceNode:
+		sliceValue := make([]interface{}, 0, len(node.Content))
+		for _, itemNode := range node.Content {
+			// the names of enum values are items themselves ("- red")
+			if itemNode.Kind == yaml.ScalarNode && path != "" {
+				c.positions[path+"."+itemNode.Value] = declarationPosition{
+					key:         nodePosition(itemNode, c.fileName),
+					value:       nodePosition(itemNode, c.fileName),
+					headComment: itemNode.HeadComment,
+					lineComment: itemNode.LineComment,
+				}
+			}
+			item, err := c.convert(itemNode, path)
+			if err != nil {
+				return nil, err
+			}
+			sliceValue = append(sliceValue, item)
+		}
+		return sliceValue, nil
+	}
+
+	// floats of constants and variables are kept as written
+	if node.ShortTag() == "!!float" && isSectionKey(strings.Split(path, ".")[0]) {
+		return floatLiteral(node.Value), nil
+	}
+
+	var value interface{}
+	err := node.Decode(&value)
+	return value, err
+}
+
+// the comment beside a scalar belongs to its value ("foo: int # ..."),
+// the comment beside an object or list to its key ("foo: # ...")
+func lineCommentOf(keyNode, valueNode *yaml.Node) string {
+	if valueNode.LineComment != "" {
+		return valueNode.LineComment
+	}
+	return keyNode.LineComment
+}
+
+// yaml.v3 attaches the comment beside the tag of an object ("reader: !interface # ...") to the
+// first key of the object; it is moved back to the key of the object, which it belongs to
+func (c *nodeConverter) moveTagLineComment(keyNode, valueNode *yaml.Node) {
+	if valueNode.Style&yaml.TaggedStyle == 0 || valueNode.Kind != yaml.MappingNode || len(valueNode.Content) == 0 {
+		return
+	}
+	firstKeyNode := valueNode.Content[0]
+	// the first key may have a comment beside it itself ("Read: # ...")
+	if firstKeyNode.LineComment == "" || firstKeyNode.Line == valueNode.Line || valueNode.Line > len(c.lines) ||
+		!strings.HasSuffix(strings.TrimSpace(c.lines[valueNode.Line-1]), firstKeyNode.LineComment) {
+		return
+	}
+	keyNode.LineComment, firstKeyNode.LineComment = firstKeyNode.LineComment, ""
+}
+
+// withOptionalFields makes keys marked as optional ("nickname?") declare optional fields
+func (c *nodeConverter) convertMapping(node *yaml.Node, path string, mapValue map[interface{}]interface{}, withOptionalFields bool) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		// "<<: *anchor" merges the keys of the anchored object
+		if keyNode.Tag == "!!merge" {
+			mergedValue, err := c.convert(valueNode, path)
+			if err != nil {
+				return err
+			}
+			if mergedMap, ok := mergedValue.(map[interface{}]interface{}); ok {
+				for mergedKey, mergedItem := range mergedMap {
+					if _, ok := mapValue[mergedKey]; !ok {
+						mapValue[mergedKey] = mergedItem
+					}
+				}
+			}
+			continue
+		}
+
+		var key interface{}
+		if err := keyNode.Decode(&key); err != nil {
+			return err
+		}
+
+		keyName := fmt.Sprintf("%v", key)
+		// "list[T any]" declares the generic type list
+		if generic, ok := parseGenericTypeName(keyName); ok && path == "" {
+			key, keyName = generic, generic.name
+		}
+		// a type cannot be declared generic and non-generic at once ("list" and "list[T any]")
+		if declaredKey, ok := declarationKeyOf(mapValue, keyName); ok && declaredKey != key && path == "" {
+			return fmt.Errorf("yaml: line %d: type \"%s\" is declared more than once", keyNode.Line, keyName)
+		}
+		// "nickname?: string" declares an optional field; fields without type (e.g. embedded fields) cannot be optional
+		isOptional := false
+		if optionalName, ok := parseOptionalFieldName(keyName); ok && withOptionalFields && resolveAlias(valueNode).ShortTag() == "!!str" {
+			key, keyName, isOptional = optionalName, optionalName, true
+		}
+		// a field cannot be declared optional and non-optional at once ("nickname" and "nickname?")
+		if declaredValue, ok := mapValue[key]; ok && withOptionalFields && isOptional != isOptionalField(declaredValue) {
+			return fmt.Errorf("yaml: line %d: field \"%s\" is declared more than once", keyNode.Line, keyName)
+		}
+		keyPath := keyName
+		if path != "" {
+			keyPath = path + "." + keyName
+		}
+
+		c.moveTagLineComment(keyNode, valueNode)
+		c.positions[keyPath] = declarationPosition{
+			key:         nodePosition(keyNode, c.fileName),
+			value:       nodePosition(valueNode, c.fileName),
+			headComment: keyNode.HeadComment,
+			lineComment: lineCommentOf(keyNode, valueNode),
+		}
+
+		value, err := c.convert(valueNode, keyPath)
+		if err != nil {
+			return err
+		}
+		// constants and variables are declared in objects under reserved keys of the root level
+		if sectionValue, ok := value.(map[interface{}]interface{}); ok && path == "" && isSectionKey(keyName) {
+			value = valueSection(sectionValue)
+		}
+		// external packages are declared in an object under a reserved key of the root level as well
+		if importValue, ok := value.(map[interface{}]interface{}); ok && path == "" && keyName == importSectionKey {
+			value = importSection(importValue)
+		}
+		if valueString, ok := value.(string); ok && isOptional && valueString != "" {
+			value = optionalFieldTypeOf(valueString, c.optionalFieldWrapper)
+		}
+		// "= user" declares an alias of user
+		if valueString, ok := value.(string); ok && path == "" && strings.HasPrefix(valueString, aliasPrefix) {
+			value = aliasDeclaration(strings.TrimSpace(strings.TrimPrefix(valueString, aliasPrefix)))
+		}
+		mapValue[key] = value
+	}
+
+	return nil
+}
+
+// sets the position of the key or value the error refers to
+func attachPositions(errs []error, positions declarationPositions) {
+	for _, err := range errs {
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			continue
+		}
+
+		position, ok := positions[declarationPathOf(validationErr.KeyName, validationErr.ParentObject)]
+		if !ok {
+			// the key is not declared in the document (e.g. promoted fields), so the object it belongs to is pointed at
+			if objectPosition, ok := positions[validationErr.ParentObject]; ok {
+				validationErr.Pos = objectPosition.key
+			}
+			continue
+		}
+
+		switch validationErr.Kind {
+		case ErrIllegalTypeName, ErrShadowedPredeclared, ErrRecursiveTypeUsage, ErrNestedTypeNameConflict, ErrEnumNameConflict,
+			WarnUnusedType, WarnFieldShadowsType, WarnMixedExportedTypeNames, WarnNamesDifferOnlyInCase:
+			validationErr.Pos = position.key
+		default:
+			validationErr.Pos = position.value
+		}
+	}
+}
+
+// Unmarshal converts the YAML type declarations into AST declarations.
+// All validation errors are returned if the document is invalid, in which case no declarations are returned.
+func Unmarshal(yamlDataBytes []byte, options ...Option) ([]ast.Decl, []error) {
+	decls, _, errs := UnmarshalWithWarnings(yamlDataBytes, options...)
+	return decls, errs
+}
+
+// UnmarshalWithWarnings works like Unmarshal but additionally returns warnings
+// about declarations that are valid but most likely not intended (e.g. unused types).
+// Warnings do not prevent the generation of declarations unless they are promoted with WithWarningsAsErrors.
+func UnmarshalWithWarnings(yamlDataBytes []byte, options ...Option) (decls []ast.Decl, warnings []error, errs []error) {
+	var c config
+	for _, option := range options {
+		option(&c)
+	}
+
+	tagger := newStructTagger(c.structTags, c.fieldTags)
+	if err := tagger.validate(); err != nil {
+		return nil, nil, []error{err}
+	}
+
+	yamlData, positions, err := convertToDataMap(yamlDataBytes, c.fileName, c.optionalFieldWrapper)
 	if err != nil {
-		return nil, []error{err}
+		return nil, nil, []error{err}
+	}
+
+	if c.namedNestedTypes {
+		var conflictErrs []error
+		yamlData, conflictErrs = hoistNestedObjects(yamlData, positions)
+		if len(conflictErrs) > 0 {
+			attachPositions(conflictErrs, positions)
+			sortValidationErrors(conflictErrs)
+			return nil, nil, conflictErrs
+		}
 	}
 
-	validationErrs := validateYamlData(yamlData)
+	var validationErrs []error
+	if c.exhaustiveValidation {
+		validationErrs = validateYamlDataExhaustive(yamlData)
+	} else {
+		validationErrs = validateYamlData(yamlData)
+	}
+
+	for _, warning := range lintYamlData(yamlData) {
+		if c.isPromotedWarning(warning) {
+			validationErrs = append(validationErrs, warning)
+		} else {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	attachPositions(warnings, positions)
+	sortValidationErrors(warnings)
+
 	if len(validationErrs) > 0 {
-		return nil, validationErrs
+		attachPositions(validationErrs, positions)
+		sortValidationErrors(validationErrs)
+		return nil, warnings, validationErrs
 	}
 
-	file := convertToAST(yamlData)
+	order := newDeclarationOrder(c.order, yamlData, positions)
+	var file *ast.File
+	if c.commentFileSet != nil {
+		file = convertToAST(yamlData, tagger, positions, order, c.commentFileSet)
+	} else {
+		file = convertToAST(yamlData, tagger, nil, order, token.NewFileSet())
+	}
 
-	return file.Decls, make([]error, 0)
+	return file.Decls, warnings, make([]error, 0)
 }
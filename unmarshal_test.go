@@ -1,12 +1,25 @@
 package yamltostruct
 
 import (
+	"bytes"
+	"errors"
 	"go/ast"
+	"go/printer"
+	"go/token"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// the text of the error prefixed with its position, if it has one ("2:6: ErrTypeNotFound: ...")
+func positionedErrorText(err error) string {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) && validationErr.Pos.IsValid() {
+		return validationErr.Pos.String() + ": " + err.Error()
+	}
+	return err.Error()
+}
+
 func TestUnmarshal(t *testing.T) {
 	t.Run("should unmarshal without errors", func(t *testing.T) {
 		yamlDataBytes := []byte(
@@ -41,8 +54,955 @@ bar:
 		)
 
 		decls, errs := Unmarshal(yamlDataBytes)
-		assert.Equal(t, errs, []error{newValidationErrorTypeNotFound("boo", "bar")})
+		expectedErr := newValidationErrorTypeNotFound("boo", "baz", "bar", "bool", "foo")
+		expectedErr.Pos = token.Position{Line: 3, Column: 8}
+		assert.Equal(t, errs, []error{expectedErr})
 		var expectedFile []ast.Decl
 		assert.Equal(t, decls, expectedFile)
 	})
+
+	t.Run("should attach positions of offending keys and values", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`foo: string
+ba$r: int
+baz:
+  ban: "[]in[t"`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes, WithFileName("types.yaml"))
+
+		expectedErrs := []string{
+			"types.yaml:2:1: ErrIllegalTypeName: illegal type name \"ba$r\" in \"root\"",
+			"types.yaml:4:8: ErrInvalidValueString: value \"[]in[t\" assigned to \"ban\" in \"baz\" is invalid",
+		}
+		var actualErrs []string
+		for _, err := range errs {
+			actualErrs = append(actualErrs, positionedErrorText(err))
+		}
+		assert.ElementsMatch(t, expectedErrs, actualErrs)
+	})
+
+	t.Run("should return type check errors at the position of the value", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`foo: string
+bar:
+  baz: "[-1]foo"`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes, WithFileName("types.yaml"))
+
+		var actualErrs []string
+		for _, err := range errs {
+			actualErrs = append(actualErrs, positionedErrorText(err))
+		}
+		assert.Equal(t, []string{
+			"types.yaml:3:8: ErrTypeCheck: declaration of \"baz\" in \"bar\" does not type-check: invalid array length -1 (untyped int constant)",
+		}, actualErrs)
+	})
+
+	t.Run("should return errors in order of their appearance", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`zed: bam
+foo:
+  ban: kan
+  bal: lan
+abc: ban`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		expectedErrs := []string{
+			"1:6: ErrTypeNotFound: type with name \"bam\" in \"root\" was not found",
+			"3:8: ErrTypeNotFound: type with name \"kan\" in \"foo\" was not found",
+			"4:8: ErrTypeNotFound: type with name \"lan\" in \"foo\" was not found",
+			"5:6: ErrTypeNotFound: type with name \"ban\" in \"root\" was not found",
+		}
+		var actualErrs []string
+		for _, err := range errs {
+			actualErrs = append(actualErrs, positionedErrorText(err))
+		}
+		assert.Equal(t, expectedErrs, actualErrs)
+	})
+
+	t.Run("should report errors of all phases in exhaustive mode", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`foo: ""
+bar: baz`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+		assert.Equal(t, 1, len(errs))
+
+		_, errs = Unmarshal(yamlDataBytes, WithExhaustiveValidation())
+		assert.Equal(t, 2, len(errs))
+		assert.True(t, errors.Is(errs[0], ErrIllegalValue))
+		assert.True(t, errors.Is(errs[1], ErrTypeNotFound))
+	})
+
+	t.Run("should attach key position to recursive type usage", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`foo:
+  bar: foo`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, 1, len(errs))
+		var validationErr *ValidationError
+		assert.True(t, errors.As(errs[0], &validationErr))
+		assert.Equal(t, token.Position{Line: 2, Column: 3}, validationErr.Pos)
+	})
+
+	t.Run("should keep values of merged anchors", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`base: &base
+  id: string
+person:
+  <<: *base
+  name: string`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type base struct{ id string }
+			type person struct {
+				id   string
+				name string
+			}`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+	t.Run("should return warnings next to declarations", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`foo: string
+bar: {}`,
+		)
+
+		decls, warnings, errs := UnmarshalWithWarnings(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		assert.Equal(t, 2, len(decls))
+		var warningTexts []string
+		for _, warning := range warnings {
+			warningTexts = append(warningTexts, positionedErrorText(warning))
+		}
+		assert.Equal(t, []string{
+			"1:1: WarnUnusedType: type \"foo\" is declared but never used",
+			"2:1: WarnUnusedType: type \"bar\" is declared but never used",
+			"2:6: WarnEmptyStruct: object \"bar\" in \"root\" has no fields",
+		}, warningTexts)
+	})
+
+	t.Run("should return promoted warnings as errors", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`foo: string
+bar: {}`,
+		)
+
+		decls, warnings, errs := UnmarshalWithWarnings(yamlDataBytes, WithWarningsAsErrors(WarnEmptyStruct))
+
+		var expectedDecls []ast.Decl
+		assert.Equal(t, expectedDecls, decls)
+		assert.Equal(t, 2, len(warnings))
+		assert.Equal(t, 1, len(errs))
+		assert.True(t, errors.Is(errs[0], WarnEmptyStruct))
+	})
+
+	t.Run("should convert nested objects", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`person:
+  name: string
+  address:
+    street: string
+    number: int`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type person struct {
+				address struct {
+					number int
+					street string
+				}
+				name string
+			}`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should attach positions of keys in nested objects", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`person:
+  address:
+    street: strin`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "3:13: ErrTypeNotFound: type with name \"strin\" in \"person.address\" was not found, did you mean \"string\"?", positionedErrorText(errs[0]))
+	})
+
+	t.Run("should convert nested objects to named types", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`person:
+  name: string
+  address:
+    street: string
+    geo:
+      lat: float64`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes, WithNamedNestedTypes())
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type person struct {
+				address person_address
+				name    string
+			}
+			type person_address struct {
+				geo    person_address_geo
+				street string
+			}
+			type person_address_geo struct{ lat float64 }`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should attach positions of nested objects converted to named types", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`person:
+  address:
+    street: strin`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes, WithNamedNestedTypes())
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "3:13: ErrTypeNotFound: type with name \"strin\" in \"person_address\" was not found, did you mean \"string\"?", positionedErrorText(errs[0]))
+	})
+
+	t.Run("should fail if names generated for nested objects are already declared", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`person_address: string
+person:
+  address:
+    street: string`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes, WithNamedNestedTypes())
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "3:3: ErrNestedTypeNameConflict: type name \"person_address\" generated for \"address\" in \"person\" is already declared", positionedErrorText(errs[0]))
+	})
+
+	t.Run("should generate struct tags", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`person:
+  firstName: string
+  password: string
+  address:
+    zipCode: string`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes,
+			WithStructTags(
+				StructTag{Key: "json", Naming: SnakeCase, OmitEmpty: true},
+				StructTag{Key: "yaml", Naming: KebabCase},
+			),
+			WithFieldTag("person.password", "json", "-"),
+			WithFieldTag("person.address.zipCode", "yaml", "zip"),
+		)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			"type person struct { " +
+				"address struct { zipCode string `json:\"zip_code,omitempty\" yaml:\"zip\"` } `json:\"address,omitempty\" yaml:\"address\"` " +
+				"firstName string `json:\"first_name,omitempty\" yaml:\"first-name\"` " +
+				"password string `json:\"-\" yaml:\"password\"` " +
+				"}",
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should reject invalid struct tag keys", func(t *testing.T) {
+		decls, errs := Unmarshal([]byte(`foo: string`), WithStructTags(StructTag{Key: "js on"}))
+
+		assert.Nil(t, decls)
+		assert.Equal(t, 1, len(errs))
+	})
+
+	t.Run("should convert embedded fields", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`audit:
+  createdAt: int64
+user:
+  audit:
+  "*base":
+  name: string
+base:
+  id: string`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type audit struct{ createdAt int64 }
+			type base struct{ id string }
+			type user struct {
+				*base
+				audit
+				name string
+			}`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should attach the position of the object to promoted field conflicts", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`audit:
+  id: int64
+base:
+  id: string
+user:
+  audit:
+  base:`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "5:1: ErrPromotedFieldConflict: field \"id\" is promoted to \"user\" by more than one embedded type (\"audit\", \"base\")", positionedErrorText(errs[0]))
+	})
+
+	t.Run("should convert interfaces", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`reader: !interface
+  Read: func(p []byte) (int, error)
+closer: !interface
+  Close: func() error
+readCloser: !interface
+  reader:
+  closer:
+files: map[readCloser]string`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type closer interface{ Close() error }
+			type files map[readCloser]string
+			type readCloser interface {
+				closer
+				reader
+			}
+			type reader interface{ Read(p []byte) (int, error) }`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should only allow objects to be tagged as interface", func(t *testing.T) {
+		_, errs := Unmarshal([]byte(`reader: !interface string`))
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "yaml: line 1: only objects can be tagged with !interface", positionedErrorText(errs[0]))
+	})
+
+	t.Run("should convert func and chan types", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`handler: func(req request, opts ...string) (res *request, err error)
+request:
+  next: handler
+  done: <-chan request
+  results: chan<- map[chan int]request`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type handler func(req request, opts ...string) (res *request, err error)
+			type request struct {
+				done    <-chan request
+				next    handler
+				results chan<- map[chan int]request
+			}`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should convert enums", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`color:
+  - red
+  - green
+car:
+  color: color`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		// the import of the enum methods comes first; the 5 methods and functions of color come last
+		assert.Equal(t, 9, len(decls))
+		output := normalizeWhitespace(printDecls(decls[:4]))
+		expectedOutput := normalizeWhitespace(
+			`import "fmt"
+			type car struct{ color color }
+			type color int
+			const (
+				colorRed color = iota
+				colorGreen
+			)`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should attach positions of enum values", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`colorGreen: int
+color:
+  - red
+  - green
+status:
+  - active: 1
+  - enabled: 1`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes, WithExhaustiveValidation())
+
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "4:5: ErrEnumNameConflict: name \"colorGreen\" generated for \"green\" in \"color\" is already declared", positionedErrorText(errs[0]))
+		assert.Equal(t, "7:14: ErrDuplicateEnumValue: value 1 of \"enabled\" in \"status\" is already assigned to \"active\"", positionedErrorText(errs[1]))
+	})
+
+	t.Run("should convert constants and variables", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`const:
+  maxPlayers: 4
+  greeting: '"hello"'
+var:
+  defaultTeam: team
+team: "[maxPlayers]string"`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`const (
+				greeting   = "hello"
+				maxPlayers = 4
+			)
+			var (
+				defaultTeam team
+			)
+			type team [maxPlayers]string`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should fail on array lengths which are no declared integer constants", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`const:
+  maxPlayers: 4
+  half: 0.5
+team: "[maxPlayrs]string"
+pair: "[half]string"`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "4:7: ErrConstantNotFound: constant with name \"maxPlayrs\" in \"root\" was not found, did you mean \"maxPlayers\"?", positionedErrorText(errs[0]))
+		assert.Equal(t, "5:7: ErrNonIntegerConstant: constant \"half\" used as array length of \"pair\" in \"root\" is not an integer", positionedErrorText(errs[1]))
+	})
+
+	t.Run("should convert aliases", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`user:
+  id: int
+legacyUser: = user
+users: "= []legacyUser"`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type legacyUser = user
+			type user struct{ id int }
+			type users = []legacyUser`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should only declare aliases on root level", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`user:
+  id: = int`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "2:7: ErrInvalidValueString: value \"= int\" assigned to \"id\" in \"user\" is invalid", positionedErrorText(errs[0]))
+	})
+
+	t.Run("should convert generic types", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`list[T any]:
+  items: "[]T"
+pair[K comparable, V any]:
+  key: K
+  value: V
+user:
+  friends: list[user]
+  index: pair[string, int]`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type list[T any] struct{ items []T }
+			type pair[K comparable, V any] struct {
+				key   K
+				value V
+			}
+			type user struct {
+				friends list[user]
+				index   pair[string, int]
+			}`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should fail on invalid instantiations of generic types", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`set[T comparable]: map[T]bool
+user:
+  friends: set
+  tags: set[[]string]`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "3:12: ErrTypeArgumentCount: type \"set\" used by \"friends\" in \"user\" takes 1 type arguments, got 0", positionedErrorText(errs[0]))
+		assert.Equal(t, "4:9: ErrUnsatisfiedConstraint: type argument \"[]string\" of \"set\" used by \"tags\" in \"user\" does not satisfy \"T comparable\"", positionedErrorText(errs[1]))
+	})
+
+	t.Run("should keep type parameters with invalid constraints in exhaustive mode", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`list[T ordered]: "[]T"
+user:
+  names: list[string]
+  ids: list`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes, WithExhaustiveValidation())
+
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "1:1: ErrTypeNotFound: type with name \"ordered\" in \"list\" was not found", positionedErrorText(errs[0]))
+		assert.Equal(t, "4:8: ErrTypeArgumentCount: type \"list\" used by \"ids\" in \"user\" takes 1 type arguments, got 0", positionedErrorText(errs[1]))
+	})
+
+	t.Run("should fail on types declared generic and non-generic", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`list: "[]string"
+list[T any]: "[]T"`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "yaml: line 2: type \"list\" is declared more than once", positionedErrorText(errs[0]))
+	})
+
+	t.Run("should convert types of external packages", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`import:
+  uuid:
+    path: github.com/google/uuid
+    types:
+      UUID: comparable
+  bytes:
+    types:
+      Buffer: incomparable
+user:
+  id: uuid.UUID
+  friends: map[uuid.UUID]user
+  avatar: "*bytes.Buffer"`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`import (
+				"bytes"
+				"github.com/google/uuid"
+			)
+			type user struct {
+				avatar  *bytes.Buffer
+				friends map[uuid.UUID]user
+				id      uuid.UUID
+			}`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should attach positions to errors in declarations of external packages", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`import:
+  bytes:
+    types:
+      Buffer: maybe
+user:
+  avatar: bytes.Buffer`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "4:15: ErrIllegalValue: value assigned to key \"Buffer\" in \"import.bytes.types\" is invalid", positionedErrorText(errs[0]))
+	})
+
+	t.Run("should carry comments over into doc and line comments", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`# a person
+#
+# with a name
+person:
+  # the first name
+  first: string # as given at birth
+  address: # where they live
+    street: string
+  name: # embedded
+  # how old they are
+  age: int
+# identifies a person
+id: string # unique
+name:
+  last: string
+const:
+  # the upper limit
+  maxAge: 150 # years
+stringer: !interface
+  # describes the value
+  String: func() string`,
+		)
+
+		fileSet := token.NewFileSet()
+		decls, errs := Unmarshal(yamlDataBytes, WithComments(fileSet))
+
+		assert.Equal(t, errs, []error{})
+		var buf bytes.Buffer
+		assert.Nil(t, printer.Fprint(&buf, fileSet, decls))
+		output := normalizeWhitespace(buf.String())
+		expectedOutput := normalizeWhitespace(
+			`const (
+				// the upper limit
+				maxAge = 150 // years
+			)
+
+			// identifies a person
+			type id string // unique
+			type name struct {
+				last string
+			}
+
+			// a person
+			//
+			// with a name
+			type person struct {
+				address struct {
+					street string
+				} // where they live
+				// how old they are
+				age int
+				// the first name
+				first string // as given at birth
+				name // embedded
+			}
+			type stringer interface {
+				// describes the value
+				String() string
+			}`,
+		)
+		assert.Equal(t, output, expectedOutput)
+
+		personDecl := decls[3].(*ast.GenDecl)
+		assert.Equal(t, "a person\n\nwith a name\n", personDecl.Doc.Text())
+		firstField := personDecl.Specs[0].(*ast.TypeSpec).Type.(*ast.StructType).Fields.List[2]
+		assert.Equal(t, "the first name\n", firstField.Doc.Text())
+		assert.Equal(t, "as given at birth\n", firstField.Comment.Text())
+	})
+
+	t.Run("should carry comments of enum values over", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`# the colors of a traffic light
+color:
+  # stop
+  - red
+  - green # go`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes, WithComments(token.NewFileSet()))
+
+		assert.Equal(t, errs, []error{})
+		typeDecl := decls[1].(*ast.GenDecl)
+		assert.Equal(t, "the colors of a traffic light\n", typeDecl.Doc.Text())
+		constDecl := decls[2].(*ast.GenDecl)
+		assert.Equal(t, "stop\n", constDecl.Specs[0].(*ast.ValueSpec).Doc.Text())
+		assert.Equal(t, "go\n", constDecl.Specs[1].(*ast.ValueSpec).Comment.Text())
+	})
+
+	t.Run("should carry comments beside tags over", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`reader: !interface # reads values
+  Read: func() int
+writer: !interface
+  Write: # writes values
+    func(int)`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes, WithComments(token.NewFileSet()))
+
+		assert.Equal(t, errs, []error{})
+		readerSpec := decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec)
+		assert.Equal(t, "reads values\n", readerSpec.Comment.Text())
+		assert.Nil(t, readerSpec.Type.(*ast.InterfaceType).Methods.List[0].Comment)
+		writerSpec := decls[1].(*ast.GenDecl).Specs[0].(*ast.TypeSpec)
+		assert.Nil(t, writerSpec.Comment)
+		assert.Equal(t, "writes values\n", writerSpec.Type.(*ast.InterfaceType).Methods.List[0].Comment.Text())
+	})
+
+	t.Run("should drop comments without WithComments", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`# identifies a person
+id: string # unique`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		typeDecl := decls[0].(*ast.GenDecl)
+		assert.Nil(t, typeDecl.Doc)
+		assert.Nil(t, typeDecl.Specs[0].(*ast.TypeSpec).Comment)
+	})
+
+	t.Run("should generate declarations in source order", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`user:
+  id: string
+  name: string
+  address:
+    street: string
+    city: string
+  created_at: int64
+group:
+  members: "[]user"`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes, WithOrder(SourceOrder), WithNamedNestedTypes())
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type user struct {
+				id         string
+				name       string
+				address    user_address
+				created_at int64
+			}
+			type user_address struct {
+				street string
+				city   string
+			}
+			type group struct{ members []user }`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should generate types in topological order", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`user:
+  id: userID
+  name: string
+group:
+  members: "[]user"
+userID: string`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes, WithOrder(TopologicalOrder))
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type userID string
+			type user struct {
+				id   userID
+				name string
+			}
+			type group struct{ members []user }`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should convert optional fields to pointers", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`node:
+  value: string
+  next?: node
+  nickname?: string
+  tags?: "[]string"`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes, WithStructTags(StructTag{Key: "json"}))
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			"type node struct {\n" +
+				"next *node `json:\"next,omitempty\"`\n" +
+				"nickname *string `json:\"nickname,omitempty\"`\n" +
+				"tags *[]string `json:\"tags,omitempty\"`\n" +
+				"value string `json:\"value\"`\n" +
+				"}",
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should wrap optional fields in the configured wrapper", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`optional[T any]:
+  value: T
+  valid: bool
+user:
+  nickname?: string`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes, WithOptionalFieldWrapper("optional"))
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`type optional[T any] struct {
+				valid bool
+				value T
+			}
+			type user struct{ nickname optional[string] }`,
+		)
+		assert.Equal(t, output, expectedOutput)
+	})
+
+	t.Run("should validate optional fields with their generated type", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`user:
+  nickname?: strin
+  friends?: map[[]int]user`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "2:14: ErrTypeNotFound: type with name \"strin\" in \"user\" was not found, did you mean \"string\"?", positionedErrorText(errs[0]))
+		assert.Equal(t, "3:13: ErrInvalidMapKey: \"[]int\" in \"*map[[]int]user\" is not a valid map key", positionedErrorText(errs[1]))
+	})
+
+	t.Run("should fail on fields declared optional and non-optional", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`user:
+  nickname: string
+  nickname?: string`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, []error{errors.New("yaml: line 3: field \"nickname\" is declared more than once")}, errs)
+	})
+
+	t.Run("should only declare optional fields in objects", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`user?: string
+stringer: !interface
+  String?: func() string
+person:
+  base?:
+  address?:
+    street: string`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes)
+
+		var keyNames []string
+		for _, err := range errs {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) && errors.Is(err, ErrIllegalTypeName) {
+				keyNames = append(keyNames, validationErr.KeyName)
+			}
+		}
+		assert.Equal(t, []string{"user?", "String?", "base?", "address?"}, keyNames)
+	})
+
+	t.Run("should reject comments in values", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`user:
+  a: "int // note"`,
+		)
+
+		_, errs := Unmarshal(yamlDataBytes, WithStructTags(StructTag{Key: "json"}))
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "2:6: ErrInvalidValueString: value \"int // note\" assigned to \"a\" in \"user\" is invalid", positionedErrorText(errs[0]))
+	})
+
+	t.Run("should keep floats of constants and variables as written", func(t *testing.T) {
+		yamlDataBytes := []byte(
+			`const:
+  x: 3.0
+  million: 1e6
+var:
+  ratio: 0.5`,
+		)
+
+		decls, errs := Unmarshal(yamlDataBytes)
+
+		assert.Equal(t, errs, []error{})
+		output := normalizeWhitespace(printDecls(decls))
+		expectedOutput := normalizeWhitespace(
+			`const (
+				million = 1e6
+				x = 3.0
+			)
+			var (
+				ratio = 0.5
+			)`,
+		)
+		assert.Equal(t, output, expectedOutput)
+
+		// "x" has to stay an untyped float constant, whose default type is float64
+		xValue := decls[0].(*ast.GenDecl).Specs[1].(*ast.ValueSpec).Values[0].(*ast.BasicLit)
+		assert.Equal(t, token.FLOAT, xValue.Kind)
+	})
 }
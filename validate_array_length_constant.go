@@ -0,0 +1,95 @@
+package yamltostruct
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// returns errors if identifiers used as array lengths ("[maxPlayers]player") are not declared
+// as constants (in the section of constants or as values of enums) or are no integers
+func validateArrayLengthConstants(yamlData map[interface{}]interface{}) (errs []error) {
+	constants := evaluateConstants(yamlData)
+
+	var constantNames []string
+	for constantName := range constants {
+		constantNames = append(constantNames, constantName)
+	}
+	sort.Strings(constantNames)
+
+	rangeTypeExpressions(yamlData, func(valueString, keyName, parentItemName string) {
+		for _, constantName := range extractArrayLengthConstants(valueString) {
+			declaredConstant, ok := constants[constantName]
+			if !ok {
+				suggestions := suggestNames(constantName, constantNames)
+				errs = append(errs, newValidationErrorConstantNotFound(constantName, keyName, parentItemName, suggestions...))
+				continue
+			}
+			if isInteger, isKnown := isIntegerConstant(declaredConstant); isKnown && !isInteger {
+				errs = append(errs, newValidationErrorNonIntegerConstant(constantName, keyName, parentItemName))
+			}
+		}
+	})
+
+	return
+}
+
+// "[n][2*m]foo" => []string{"n", "m"}
+func extractArrayLengthConstants(typeDefinitionString string) (constantNames []string) {
+	typeExpr, err := parseTypeExpr(typeDefinitionString)
+	if err != nil {
+		return
+	}
+
+	walkTypeReferences(typeExpr, func(name string, isArrayLength bool) {
+		if isArrayLength {
+			constantNames = append(constantNames, name)
+		}
+	})
+	return
+}
+
+// the values of constants may depend on other constants ("maxTeams: maxPlayers / 2"),
+// so they are evaluated by go/types; errors are reported by the type check later on
+func evaluateConstants(yamlData map[interface{}]interface{}) map[string]*types.Const {
+	constants := make(map[string]*types.Const)
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", writeSourceCode(yamlData, nil, nil, nil, false).sourceCode, 0)
+	if err != nil {
+		return constants
+	}
+
+	conf := types.Config{Importer: newExternalImporter(yamlData), Error: func(error) {}}
+	pkg, _ := conf.Check(mockPackageName, fileSet, []*ast.File{file}, nil)
+	if pkg == nil {
+		return constants
+	}
+
+	for _, name := range pkg.Scope().Names() {
+		if declaredConstant, ok := pkg.Scope().Lookup(name).(*types.Const); ok {
+			constants[name] = declaredConstant
+		}
+	}
+	return constants
+}
+
+// untyped constants have to be representable as integers ("4.0" is, "2.5" is not),
+// typed ones need an integer type; the value of constants with errors is unknown
+func isIntegerConstant(declaredConstant *types.Const) (isInteger, isKnown bool) {
+	if declaredConstant.Val().Kind() == constant.Unknown {
+		return false, false
+	}
+
+	basicType, ok := declaredConstant.Type().Underlying().(*types.Basic)
+	if !ok {
+		return false, true
+	}
+	if basicType.Info()&types.IsUntyped == 0 {
+		return basicType.Info()&types.IsInteger != 0, true
+	}
+	return constant.ToInt(declaredConstant.Val()).Kind() == constant.Int, true
+}
@@ -0,0 +1,110 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYamlDataArrayLengthConstant(t *testing.T) {
+	t.Run("should not fail on declared integer constants", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": valueSection{
+				"maxPlayers": 4,
+				"maxTeams":   "maxPlayers / 2",
+				"size":       map[interface{}]interface{}{"type": "uint8", "value": 3},
+				"whole":      4.0,
+			},
+			"var": valueSection{
+				"board": "[maxTeams][maxPlayers]int",
+			},
+			"color": []interface{}{"red", "green"},
+			"foo":   "[whole]int",
+			"bar": map[interface{}]interface{}{
+				"ban": "[size]string",
+				"bam": map[interface{}]interface{}{
+					"bal": "[2 * colorGreen]string",
+				},
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on constants which are not declared", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": valueSection{
+				"maxPlayers": 4,
+			},
+			"var": valueSection{
+				"board": "[maxPlayrs]int",
+			},
+			"foo": "string",
+			"bar": map[interface{}]interface{}{
+				"ban": "[foo]string",
+			},
+			"baz": interfaceDeclaration{
+				"get": "func() [n]int",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorConstantNotFound("maxPlayrs", "board", "var", "maxPlayers"),
+			newValidationErrorConstantNotFound("foo", "ban", "bar"),
+			newValidationErrorConstantNotFound("n", "get", "baz"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on constants which are no integers", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": valueSection{
+				"half":     2.5,
+				"name":     `"foo"`,
+				"typed":    map[interface{}]interface{}{"type": "float64", "value": 4},
+				"invalid":  "undefined + 1",
+				"positive": true,
+			},
+			"foo": "[half]int",
+			"bar": map[interface{}]interface{}{
+				"ban": "[name]string",
+				"bam": "[typed]string",
+				"bal": "[positive]string",
+			},
+			// the type check reports the invalid constant
+			"baz": "[invalid]int",
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorNonIntegerConstant("half", "foo", "root"),
+			newValidationErrorNonIntegerConstant("name", "ban", "bar"),
+			newValidationErrorNonIntegerConstant("typed", "bam", "bar"),
+			newValidationErrorNonIntegerConstant("positive", "bal", "bar"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
+
+func TestExtractArrayLengthConstants(t *testing.T) {
+	t.Run("should extract identifiers used as array lengths", func(t *testing.T) {
+		assert.Equal(t, []string{"n", "m"}, extractArrayLengthConstants("[n][2*m]foo"))
+		assert.Equal(t, []string{"n"}, extractArrayLengthConstants("map[[n]int]struct{ a [3]foo }"))
+		assert.Empty(t, extractArrayLengthConstants("[]foo"))
+	})
+}
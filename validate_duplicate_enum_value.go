@@ -0,0 +1,26 @@
+package yamltostruct
+
+import (
+	"strconv"
+)
+
+// returns errors if values of an enum are assigned the same integer ("- red: 1", "- blue: 1");
+// the generated String method could not tell them apart
+func validateDuplicateEnumValues(yamlData map[interface{}]interface{}) (errs []error) {
+	rangeInAlphabeticalOrder(yamlData, func(keyName string, value interface{}) {
+		if !isEnum(value) {
+			return
+		}
+
+		namesByValue := make(map[int]string)
+		for _, enumValue := range enumValuesOf(value.([]interface{})) {
+			if relatedName, ok := namesByValue[enumValue.value]; ok {
+				errs = append(errs, newValidationErrorDuplicateEnumValue(strconv.Itoa(enumValue.value), enumValue.name, relatedName, keyName))
+				continue
+			}
+			namesByValue[enumValue.value] = enumValue.name
+		}
+	})
+
+	return
+}
@@ -0,0 +1,50 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYamlDataDuplicateEnumValue(t *testing.T) {
+	t.Run("should not fail on distinct values", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color": []interface{}{"red", "green"},
+			"status": []interface{}{
+				map[interface{}]interface{}{"active": 1},
+				"inactive",
+				map[interface{}]interface{}{"deleted": -1},
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on values assigned several times", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"status": []interface{}{
+				map[interface{}]interface{}{"active": 1},
+				map[interface{}]interface{}{"enabled": 1},
+				"inactive",
+				map[interface{}]interface{}{"deleted": 2},
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorDuplicateEnumValue("1", "enabled", "active", "status"),
+			newValidationErrorDuplicateEnumValue("2", "deleted", "inactive", "status"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
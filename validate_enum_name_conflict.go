@@ -0,0 +1,65 @@
+package yamltostruct
+
+import (
+	"go/token"
+)
+
+// returns errors if names generated for enums (constants and parse functions) are already
+// declared as types, constants or variables or generated for other enums; the generated
+// methods import a package, so its name may not be declared either (unless as that package)
+func validateEnumNameConflicts(yamlData map[interface{}]interface{}) (errs []error) {
+	generatedNames := make(map[string]bool)
+	rangeValueSections(yamlData, func(_ string, section valueSection) {
+		rangeInAlphabeticalOrder(section, func(keyName string, _ interface{}) {
+			generatedNames[keyName] = true
+		})
+	})
+	isDeclared := func(name string) bool {
+		_, isDeclaredType := declarationOf(yamlData, name)
+		return isDeclaredType || generatedNames[name]
+	}
+
+	var importChecked bool
+	rangeTypeDeclarations(yamlData, func(keyName string, value interface{}) {
+		if !isEnum(value) || isIllegalTypeName(keyName) {
+			return
+		}
+
+		if isEnumImportNameTaken(yamlData, isDeclared) && !importChecked {
+			errs = append(errs, newValidationErrorEnumNameConflict(enumImportName, keyName, "root"))
+		}
+		importChecked = true
+
+		parseFuncName := enumParseFuncName(keyName)
+		if isDeclared(parseFuncName) {
+			errs = append(errs, newValidationErrorEnumNameConflict(parseFuncName, keyName, "root"))
+		}
+		generatedNames[parseFuncName] = true
+
+		for _, enumValue := range enumValuesOf(value.([]interface{})) {
+			if !token.IsIdentifier(enumValue.name) {
+				continue
+			}
+			constantName := enumConstantName(keyName, enumValue.name)
+			if isDeclared(constantName) {
+				errs = append(errs, newValidationErrorEnumNameConflict(constantName, enumValue.name, keyName))
+			}
+			generatedNames[constantName] = true
+		}
+	})
+
+	return
+}
+
+// the name of the package the enum methods use may only be declared as that very package
+func isEnumImportNameTaken(yamlData map[interface{}]interface{}, isDeclared func(name string) bool) bool {
+	if isDeclared(enumImportName) {
+		return true
+	}
+	for _, externalPkg := range externalPackagesOf(yamlData) {
+		if externalPkg.name == enumImportName && externalPkg.path != enumImportName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYamlDataEnumNameConflict(t *testing.T) {
+	t.Run("should not fail on unique generated names", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color":  []interface{}{"red", "green"},
+			"Status": []interface{}{map[interface{}]interface{}{"active": 1}, "inactive"},
+			"car": map[interface{}]interface{}{
+				"colorRed": "color",
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on generated names which are already declared", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color":      []interface{}{"red", "green"},
+			"colorGreen": "int",
+			"parseColor": "string",
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorEnumNameConflict("colorGreen", "green", "color"),
+			newValidationErrorEnumNameConflict("parseColor", "color", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on names generated several times", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color":  []interface{}{"red", "Red", "blue"},
+			"colorR": []interface{}{"ed"},
+			"parse":  []interface{}{"color"},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorEnumNameConflict("colorRed", "Red", "color"),
+			newValidationErrorEnumNameConflict("parseColor", "color", "parse"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on types named like the package imported by enums", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color": []interface{}{"red"},
+			"fmt":   "string",
+			"size":  []interface{}{"small"},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorEnumNameConflict("fmt", "color", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail if the package used by enum methods is declared with another path", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": importSection{
+				"fmt": map[interface{}]interface{}{"path": "example.com/fmt"},
+			},
+			"color": []interface{}{"red"},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorEnumNameConflict("fmt", "color", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on constants named like the package imported by enums", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color": []interface{}{"red"},
+			"const": valueSection{"fmt": 1},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorEnumNameConflict("fmt", "color", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on variables named like the package imported by enums", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color": []interface{}{"red"},
+			"var":   valueSection{"fmt": "int"},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorEnumNameConflict("fmt", "color", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
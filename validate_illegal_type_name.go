@@ -1,17 +1,14 @@
 package yamltostruct
 
 import (
-	"fmt"
-	"go/parser"
 	"go/token"
 )
 
 // returns errors if type names contain illegal characters that do not adhere to golangs syntax restrictions
 func validateIllegalTypeName(yamlData map[interface{}]interface{}) (errs []error) {
-	for key, value := range yamlData {
-		keyName := fmt.Sprintf("%v", key)
-
-		if isIllegalTypeName(keyName) {
+	rangeTypeDeclarations(yamlData, func(keyName string, value interface{}) {
+		// enums are declared as int types, so they cannot have type parameters
+		if isIllegalTypeName(keyName) || (isEnum(value) && typeParamsOf(yamlData, keyName) != nil) {
 			errs = append(errs, newValidationErrorIllegalTypeName(keyName, "root"))
 		}
 
@@ -20,27 +17,107 @@ func validateIllegalTypeName(yamlData map[interface{}]interface{}) (errs []error
 			objectValidationErrs := validateIllegalTypeNameObject(mapValue, keyName)
 			errs = append(errs, objectValidationErrs...)
 		}
+
+		if isInterface(value) {
+			interfaceValidationErrs := validateIllegalTypeNameInterface(value.(interfaceDeclaration), keyName)
+			errs = append(errs, interfaceValidationErrs...)
+		}
+
+		if isEnum(value) {
+			enumValidationErrs := validateIllegalTypeNameEnum(value.([]interface{}), keyName)
+			errs = append(errs, enumValidationErrs...)
+		}
+	})
+
+	if section, ok := yamlData[importSectionKey].(importSection); ok {
+		importValidationErrs := validateIllegalTypeNameImports(section)
+		errs = append(errs, importValidationErrs...)
+	}
+
+	// the names of constants and variables are checked just like field names
+	rangeValueSections(yamlData, func(sectionKey string, section valueSection) {
+		rangeInAlphabeticalOrder(section, func(keyName string, _ interface{}) {
+			if isIllegalTypeName(keyName) {
+				errs = append(errs, newValidationErrorIllegalTypeName(keyName, sectionKey))
+			}
+		})
+	})
+
+	return
+}
+
+// packages are referred to by their names and their types by qualified identifiers ("uuid.UUID"),
+// which can only refer to exported types; the blank identifier cannot be referred to at all
+func validateIllegalTypeNameImports(section importSection) (errs []error) {
+	rangeInAlphabeticalOrder(section, func(packageName string, value interface{}) {
+		if isIllegalTypeName(packageName) || packageName == "_" {
+			errs = append(errs, newValidationErrorIllegalTypeName(packageName, importSectionKey))
+		}
+
+		packageData, _ := value.(map[interface{}]interface{})
+		typesData, ok := packageData[importTypesKey].(map[interface{}]interface{})
+		if !ok {
+			return
+		}
+		typesPath := declarationPathOf(importTypesKey, declarationPathOf(packageName, importSectionKey))
+		rangeInAlphabeticalOrder(typesData, func(typeName string, _ interface{}) {
+			if isIllegalTypeName(typeName) || !token.IsExported(typeName) {
+				errs = append(errs, newValidationErrorIllegalTypeName(typeName, typesPath))
+			}
+		})
+	})
+	return
+}
+
+// the names of enum values are also used as constant names ("red" => "colorRed")
+func validateIllegalTypeNameEnum(yamlEnumData []interface{}, enumName string) (errs []error) {
+	for _, value := range enumValuesOf(yamlEnumData) {
+		if isIllegalTypeName(value.name) {
+			errs = append(errs, newValidationErrorIllegalTypeName(value.name, enumName))
+		}
 	}
+	return
+}
 
+// method names and embedded interfaces have to be identifiers; interfaces cannot be embedded as pointers
+func validateIllegalTypeNameInterface(yamlInterfaceData interfaceDeclaration, interfaceName string) (errs []error) {
+	rangeInAlphabeticalOrder(yamlInterfaceData, func(keyName string, _ interface{}) {
+		if isIllegalTypeName(keyName) {
+			errs = append(errs, newValidationErrorIllegalTypeName(keyName, interfaceName))
+		}
+	})
 	return
 }
 
 func validateIllegalTypeNameObject(yamlObjectData map[interface{}]interface{}, objectName string) (errs []error) {
-	for key := range yamlObjectData {
-		keyName := fmt.Sprintf("%v", key)
+	rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+		if isEmbeddedField(value) {
+			if isIllegalEmbeddedTypeName(keyName) {
+				errs = append(errs, newValidationErrorIllegalTypeName(keyName, objectName))
+			}
+			return
+		}
 
 		if isIllegalTypeName(keyName) {
 			errs = append(errs, newValidationErrorIllegalTypeName(keyName, objectName))
 		}
-	}
+
+		if isMap(value) {
+			mapValue := value.(map[interface{}]interface{})
+			objectValidationErrs := validateIllegalTypeNameObject(mapValue, declarationPathOf(keyName, objectName))
+			errs = append(errs, objectValidationErrs...)
+		}
+	})
 	return
 }
 
+// names are spliced into the generated source code, so anything
+// but a single identifier (which is not a keyword) is illegal
 func isIllegalTypeName(typeName string) bool {
-	sourceCodeMock := `
-	package main
-	type ` + typeName + ` string`
+	return !token.IsIdentifier(typeName)
+}
 
-	_, err := parser.ParseFile(token.NewFileSet(), "", sourceCodeMock, 0)
-	return err != nil
+// embedded fields are named after the type they embed ("audit", "*audit")
+func isIllegalEmbeddedTypeName(typeName string) bool {
+	return isIllegalTypeName(embeddedTypeName(typeName))
 }
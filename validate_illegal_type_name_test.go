@@ -109,6 +109,170 @@ func TestValidateYamlDataIllegalTypeName(t *testing.T) {
 		assert.Empty(t, missingErrors)
 		assert.Empty(t, redundantErrors)
 	})
+
+	t.Run("should fail on illegal names in nested objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"ba$r": map[interface{}]interface{}{
+					"ba z": "int",
+				},
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("ba$r", "foo"),
+			newValidationErrorIllegalTypeName("ba z", "foo.ba$r"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on illegal names of embedded fields", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar":   nil,
+				"*baz":  nil,
+				"**ban": nil,
+				"ba$":   nil,
+				"*bam":  "int",
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("**ban", "foo"),
+			newValidationErrorIllegalTypeName("ba$", "foo"),
+			newValidationErrorIllegalTypeName("*bam", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on illegal method names and embedded interfaces", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": interfaceDeclaration{
+				"Read":  "func() int",
+				"bar":   nil,
+				"*baz":  nil,
+				"ba$":   "func()",
+				"close": "func()",
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("*baz", "foo"),
+			newValidationErrorIllegalTypeName("ba$", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on illegal names of enum values", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color": []interface{}{
+				"red",
+				"light blue",
+				map[interface{}]interface{}{"gr$en": 3},
+				"type",
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("light blue", "color"),
+			newValidationErrorIllegalTypeName("gr$en", "color"),
+			newValidationErrorIllegalTypeName("type", "color"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on illegal names of constants and variables", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": valueSection{
+				"foo":  1,
+				"ba$":  2,
+				"func": 3,
+			},
+			"var": valueSection{
+				"bar":     "int",
+				"ba r":    "int",
+				"bar.baz": "int",
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("ba$", "const"),
+			newValidationErrorIllegalTypeName("func", "const"),
+			newValidationErrorIllegalTypeName("ba r", "var"),
+			newValidationErrorIllegalTypeName("bar.baz", "var"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on enums with type parameters", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"color", "[T any]"}: []interface{}{"red"},
+			genericTypeName{"list", "[T any]"}:  "[]T",
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("color", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on illegal package names and unexported external types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": importSection{
+				"_": map[interface{}]interface{}{},
+				"my pkg": map[interface{}]interface{}{
+					"path": "example.com/mypkg",
+				},
+				"uuid": map[interface{}]interface{}{
+					"path":  "github.com/google/uuid",
+					"types": map[interface{}]interface{}{"UUID": "comparable", "uuid": "comparable", "Nu$$": "comparable"},
+				},
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("_", "import"),
+			newValidationErrorIllegalTypeName("my pkg", "import"),
+			newValidationErrorIllegalTypeName("uuid", "import.uuid.types"),
+			newValidationErrorIllegalTypeName("Nu$$", "import.uuid.types"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
 }
 
 func TestIsIllegalTypeName(t *testing.T) {
@@ -118,4 +282,10 @@ func TestIsIllegalTypeName(t *testing.T) {
 	t.Run("should return true if the type names are illegal", func(t *testing.T) {
 		assert.Equal(t, true, isIllegalTypeName("fo o"), isIllegalTypeName("b*ar"), isIllegalTypeName("B+2Z"))
 	})
+	t.Run("should return true if the type names inject code", func(t *testing.T) {
+		assert.Equal(t, true, isIllegalTypeName("a string; func init() { panic(1) }; type b"))
+		assert.Equal(t, true, isIllegalTypeName("a int\n}\nfunc init() {}\ntype b struct {"))
+		assert.Equal(t, true, isIllegalTypeName("foo "))
+		assert.Equal(t, true, isIllegalTypeName(""))
+	})
 }
@@ -1,59 +1,172 @@
 package yamltostruct
 
-import (
-	"fmt"
-)
-
 // returns errors if invalid values are used in the YAML file
-// the declarations may not contain: Objects in Objects, Lists, "" and nil
+// the declarations may not contain: Lists (except for enums on root level), "" and nil (except for embedded fields in objects)
 func validateIllegalValue(yamlData map[interface{}]interface{}) (errs []error) {
 
-	for key, value := range yamlData {
-		keyName := fmt.Sprintf("%v", key)
+	rangeInAlphabeticalOrder(yamlData, func(keyName string, value interface{}) {
+		if isSectionKey(keyName) {
+			sectionValidationErrs := validateIllegalValueSection(value, keyName)
+			errs = append(errs, sectionValidationErrs...)
+			return
+		}
+
+		if keyName == importSectionKey {
+			importValidationErrs := validateIllegalValueImports(value)
+			errs = append(errs, importValidationErrs...)
+			return
+		}
 
 		if isString(value) {
 			if isEmptyString(value) {
 				errs = append(errs, newValidationErrorIllegalValue(keyName, "root"))
 			}
-			continue
+			return
+		}
+
+		if isEnum(value) {
+			if isIllegalEnumValue(value.([]interface{})) {
+				errs = append(errs, newValidationErrorIllegalValue(keyName, "root"))
+			}
+			return
 		}
 
-		if isSlice(value) || isNil(value) {
+		if isNil(value) {
 			errs = append(errs, newValidationErrorIllegalValue(keyName, "root"))
-			continue
+			return
 		}
 
 		if isMap(value) {
 			mapValue := value.(map[interface{}]interface{})
 			objectValidationErrs := validateIllegalValueObject(mapValue, keyName)
 			errs = append(errs, objectValidationErrs...)
-			continue
+			return
+		}
+
+		if isInterface(value) {
+			interfaceValidationErrs := validateIllegalValueInterface(value.(interfaceDeclaration), keyName)
+			errs = append(errs, interfaceValidationErrs...)
+			return
 		}
 
 		errs = append(errs, newValidationErrorIllegalValue(keyName, "root"))
+	})
+
+	return
+}
+
+// sections have to be objects; constants need a value, variables a type or a value
+func validateIllegalValueSection(value interface{}, sectionKey string) (errs []error) {
+	if !isValueSection(value) {
+		return []error{newValidationErrorIllegalValue(sectionKey, "root")}
 	}
 
+	rangeInAlphabeticalOrder(value.(valueSection), func(keyName string, value interface{}) {
+		if _, _, ok := valueDeclarationOf(sectionKey, value); !ok {
+			errs = append(errs, newValidationErrorIllegalValue(keyName, sectionKey))
+		}
+	})
+
 	return
 }
 
-func validateIllegalValueObject(yamlObjectData map[interface{}]interface{}, objectName string) (errs []error) {
-	for key, value := range yamlObjectData {
-		keyName := fmt.Sprintf("%v", key)
+// packages have to be objects with an optional path and the types of the package,
+// each of which is either "comparable" or "incomparable"
+func validateIllegalValueImports(value interface{}) (errs []error) {
+	if !isImportSection(value) {
+		return []error{newValidationErrorIllegalValue(importSectionKey, "root")}
+	}
+
+	rangeInAlphabeticalOrder(value.(importSection), func(packageName string, packageValue interface{}) {
+		packageData, ok := packageValue.(map[interface{}]interface{})
+		if !ok {
+			errs = append(errs, newValidationErrorIllegalValue(packageName, importSectionKey))
+			return
+		}
+
+		packagePath := declarationPathOf(packageName, importSectionKey)
+		rangeInAlphabeticalOrder(packageData, func(keyName string, item interface{}) {
+			switch keyName {
+			case importPathKey:
+				if !isString(item) || isEmptyString(item) {
+					errs = append(errs, newValidationErrorIllegalValue(keyName, packagePath))
+				}
+			case importTypesKey:
+				typesData, ok := item.(map[interface{}]interface{})
+				if !ok {
+					errs = append(errs, newValidationErrorIllegalValue(keyName, packagePath))
+					return
+				}
+				rangeInAlphabeticalOrder(typesData, func(typeName string, comparability interface{}) {
+					if _, ok := parseComparability(comparability); !ok {
+						errs = append(errs, newValidationErrorIllegalValue(typeName, declarationPathOf(keyName, packagePath)))
+					}
+				})
+			default:
+				errs = append(errs, newValidationErrorIllegalValue(keyName, packagePath))
+			}
+		})
+	})
+
+	return
+}
+
+// interfaces may only contain methods and embedded interfaces
+func validateIllegalValueInterface(yamlInterfaceData interfaceDeclaration, interfaceName string) (errs []error) {
+	rangeInAlphabeticalOrder(yamlInterfaceData, func(keyName string, value interface{}) {
+		if isEmbeddedField(value) {
+			return
+		}
+
+		if !isString(value) || isEmptyString(value) {
+			errs = append(errs, newValidationErrorIllegalValue(keyName, interfaceName))
+		}
+	})
 
+	return
+}
+
+func validateIllegalValueObject(yamlObjectData map[interface{}]interface{}, objectName string) (errs []error) {
+	rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
 		if isString(value) {
 			if isEmptyString(value) {
 				errs = append(errs, newValidationErrorIllegalValue(keyName, objectName))
 			}
-			continue
+			return
+		}
+
+		if isEmbeddedField(value) {
+			return
 		}
 
-		if isSlice(value) || isMap(value) || isNil(value) {
+		if isSlice(value) {
 			errs = append(errs, newValidationErrorIllegalValue(keyName, objectName))
-			continue
+			return
+		}
+
+		// objects in objects become nested struct types
+		if isMap(value) {
+			mapValue := value.(map[interface{}]interface{})
+			objectValidationErrs := validateIllegalValueObject(mapValue, declarationPathOf(keyName, objectName))
+			errs = append(errs, objectValidationErrs...)
+			return
 		}
 
 		errs = append(errs, newValidationErrorIllegalValue(keyName, objectName))
-	}
+	})
 
 	return
 }
+
+// enums need at least one value; each item has to be a name or a name with an integer ("- red: 1")
+func isIllegalEnumValue(yamlEnumData []interface{}) bool {
+	if len(yamlEnumData) == 0 {
+		return true
+	}
+	for _, item := range yamlEnumData {
+		if _, _, _, ok := parseEnumItem(item); !ok {
+			return true
+		}
+	}
+	return false
+}
@@ -52,7 +52,6 @@ func TestValidateYamlIllegalValue(t *testing.T) {
 			"foo": nil,
 			"bar": "",
 			"baz": map[interface{}]interface{}{
-				"ban": nil,
 				"baf": "",
 			},
 		}
@@ -61,7 +60,6 @@ func TestValidateYamlIllegalValue(t *testing.T) {
 		expectedErrors := []error{
 			newValidationErrorIllegalValue("foo", "root"),
 			newValidationErrorIllegalValue("bar", "root"),
-			newValidationErrorIllegalValue("ban", "baz"),
 			newValidationErrorIllegalValue("baf", "baz"),
 		}
 
@@ -94,19 +92,239 @@ func TestValidateYamlIllegalValue(t *testing.T) {
 		assert.Empty(t, redundantErrors)
 	})
 
-	t.Run("should fail on usage of invalid nested object values", func(t *testing.T) {
+	t.Run("should not fail on usage of nested objects", func(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"foo": "int",
 			"bar": "string",
 			"baz": map[interface{}]interface{}{
 				"ban":  "int32",
 				"bant": map[interface{}]interface{}{},
+				"bam": map[interface{}]interface{}{
+					"lan": map[interface{}]interface{}{
+						"kan": "int",
+					},
+				},
+			},
+		}
+
+		actualErrors := structuralValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on usage of invalid nested object values", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "int",
+			"bar": "string",
+			"baz": map[interface{}]interface{}{
+				"ban": "int32",
+				"bant": map[interface{}]interface{}{
+					"lan": "",
+					"kan": []string{"foo"},
+					"fan": map[interface{}]interface{}{
+						"ran": "",
+					},
+				},
+			},
+		}
+
+		actualErrors := structuralValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalValue("lan", "baz.bant"),
+			newValidationErrorIllegalValue("kan", "baz.bant"),
+			newValidationErrorIllegalValue("ran", "baz.bant.fan"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should not fail on embedded fields", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "int",
+			"baz": map[interface{}]interface{}{
+				"foo":  nil,
+				"*bar": nil,
+				"ban": map[interface{}]interface{}{
+					"foo": nil,
+				},
+			},
+		}
+
+		actualErrors := structuralValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on usage of invalid interface values", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": interfaceDeclaration{
+				"bar": "func() int",
+				"baz": nil,
+				"ban": "",
+				"bam": map[interface{}]interface{}{
+					"bal": "func()",
+				},
+				"lan": []string{"func()"},
+			},
+			"bar": map[interface{}]interface{}{
+				"baz": interfaceDeclaration{},
+			},
+		}
+
+		actualErrors := structuralValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalValue("ban", "foo"),
+			newValidationErrorIllegalValue("bam", "foo"),
+			newValidationErrorIllegalValue("lan", "foo"),
+			newValidationErrorIllegalValue("baz", "bar"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should not fail on enums", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color": []interface{}{"red", "green"},
+			"status": []interface{}{
+				map[interface{}]interface{}{"active": 1},
+				"inactive",
+			},
+		}
+
+		actualErrors := structuralValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on invalid enums", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": []interface{}{},
+			"bar": []interface{}{"red", ""},
+			"baz": []interface{}{"red", 1},
+			"ban": []interface{}{map[interface{}]interface{}{"red": "1"}},
+			"bam": []interface{}{map[interface{}]interface{}{"red": 1, "green": 2}},
+			"lan": map[interface{}]interface{}{
+				"kan": []interface{}{"red"},
+			},
+		}
+
+		actualErrors := structuralValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalValue("foo", "root"),
+			newValidationErrorIllegalValue("bar", "root"),
+			newValidationErrorIllegalValue("baz", "root"),
+			newValidationErrorIllegalValue("ban", "root"),
+			newValidationErrorIllegalValue("bam", "root"),
+			newValidationErrorIllegalValue("kan", "lan"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on invalid constants and variables", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": valueSection{
+				"foo": 1,
+				"bar": map[interface{}]interface{}{"type": "int"},
+				"baz": nil,
 			},
+			"var": valueSection{
+				"foo": "int",
+				"bar": map[interface{}]interface{}{"value": 1, "tag": "json"},
+				"baz": "",
+			},
+		}
+
+		actualErrors := structuralValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalValue("bar", "const"),
+			newValidationErrorIllegalValue("baz", "const"),
+			newValidationErrorIllegalValue("bar", "var"),
+			newValidationErrorIllegalValue("baz", "var"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on sections which are no objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": 1,
+			"var":   []interface{}{"foo"},
+		}
+
+		actualErrors := structuralValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalValue("const", "root"),
+			newValidationErrorIllegalValue("var", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on invalid declarations of external packages", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": importSection{
+				"time": map[interface{}]interface{}{
+					"types": map[interface{}]interface{}{"Time": "comparable"},
+				},
+				"uuid": map[interface{}]interface{}{
+					"path":    "",
+					"version": "v1",
+					"types":   map[interface{}]interface{}{"UUID": "maybe"},
+				},
+				"yaml": "gopkg.in/yaml.v3",
+			},
+		}
+
+		actualErrors := structuralValidation(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalValue("path", "import.uuid"),
+			newValidationErrorIllegalValue("version", "import.uuid"),
+			newValidationErrorIllegalValue("UUID", "import.uuid.types"),
+			newValidationErrorIllegalValue("yaml", "import"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail if external packages are not declared in an object", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": []interface{}{"time"},
 		}
 
 		actualErrors := structuralValidation(data)
 		expectedErrors := []error{
-			newValidationErrorIllegalValue("bant", "baz"),
+			newValidationErrorIllegalValue("import", "root"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
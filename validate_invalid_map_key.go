@@ -3,117 +3,105 @@ package yamltostruct
 import (
 	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/token"
 )
 
-func extracpMapDeclExpression(file *ast.File) ast.Expr {
-	return file.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type
-}
-
-func findMapTypeRecursive(expr ast.Expr) *ast.MapType {
-	if mapType, ok := expr.(*ast.MapType); ok {
-		return mapType
-	}
-	if arrayType, ok := expr.(*ast.ArrayType); ok {
-		return findMapTypeRecursive(arrayType.Elt)
-	}
-	if starType, ok := expr.(*ast.StarExpr); ok {
-		return findMapTypeRecursive(starType.X)
-	}
-	return nil
+// calls fn for the key of every map within the type expression, including
+// maps used as keys, struct fields, parameters or channel elements
+func rangeMapKeys(valueString string, typeExpr ast.Expr, fn func(mapKey string, mapKeyExpr ast.Expr)) {
+	ast.Inspect(typeExpr, func(n ast.Node) bool {
+		if mapType, ok := n.(*ast.MapType); ok {
+			mapKey := exprSource(valueString, mapType.Key)
+			fn(mapKey, mapType.Key)
+		}
+		return true
+	})
 }
 
-func extractMapKeyRecursive(mapKeys []string, mapType *ast.MapType, mockSrc string) []string {
+// "map[int]map[*foo]int" => []string{"int", "*foo"}
+func extractMapKeys(valueString string) []string {
+	mapKeys := []string{}
 
-	if mapType == nil || mapType.Key == nil {
+	typeExpr, err := parseTypeExpr(valueString)
+	if err != nil {
 		return mapKeys
 	}
 
-	keyIdent, ok := mapType.Key.(*ast.Ident)
-	// map[string]int -> true; "string" is the Ident
-	// map[[]string]int, map[map[int]string]int .. -> false; map keys are not Idents
-	if !ok {
-		mapKey := mockSrc[mapType.Key.Pos()-1 : mapType.Key.End()-1]
+	rangeMapKeys(valueString, typeExpr, func(mapKey string, _ ast.Expr) {
 		mapKeys = append(mapKeys, mapKey)
-	} else {
-		mapKeys = append(mapKeys, keyIdent.Name)
-	}
-
-	mapKeys = extractMapKeyRecursive(mapKeys, findMapTypeRecursive(mapType.Value), mockSrc)
+	})
 
 	return mapKeys
 }
 
-func extractMapKeys(valueString string) []string {
-	mockSrc := `
-	package main
-	type mockType ` + valueString
-
-	file, _ := parser.ParseFile(token.NewFileSet(), "", mockSrc, 0)
-
-	typeExpression := extracpMapDeclExpression(file)
-	mapType, ok := typeExpression.(*ast.MapType)
-	if !ok {
-		return []string{}
-	}
-
-	return extractMapKeyRecursive([]string{}, mapType, mockSrc)
-}
-
+// returns all map keys of the value string whose types are not comparable
 func findIllegalMapKeys(valueString string, yamlData map[interface{}]interface{}) []string {
-	mapKeys := extractMapKeys(valueString)
-	if len(mapKeys) == 0 {
+	typeExpr, err := parseTypeExpr(valueString)
+	if err != nil {
 		return nil
 	}
 
 	var invalidMapKeys []string
 
-	for _, mapKey := range mapKeys {
-		var isInvalid bool
-		if isReferenceType(mapKey) {
-			isInvalid = true
-		}
-		if containsUncomparableValue(mapKey, yamlData) {
-			isInvalid = true
-		}
-		if isInvalid {
+	rangeMapKeys(valueString, typeExpr, func(mapKey string, mapKeyExpr ast.Expr) {
+		if !isComparable(mapKeyExpr, yamlData) {
 			invalidMapKeys = append(invalidMapKeys, mapKey)
 		}
-	}
+	})
 
 	return invalidMapKeys
 }
 
 func validateIllegalMapKeys(yamlData map[interface{}]interface{}) (errs []error) {
-	for _, value := range yamlData {
-
+	rangeInAlphabeticalOrder(yamlData, func(keyName string, value interface{}) {
 		if isString(value) {
 			valueString := fmt.Sprintf("%v", value)
 			illegalMapKeys := findIllegalMapKeys(valueString, yamlData)
 			for _, illegalMapKey := range illegalMapKeys {
-				errs = append(errs, newValidationErrorInvalidMapKey(illegalMapKey, valueString))
+				errs = append(errs, newValidationErrorInvalidMapKey(illegalMapKey, valueString, keyName, "root"))
 			}
-			continue
+			return
 		}
 
 		if isMap(value) {
 			mapValue := value.(map[interface{}]interface{})
-			objectValidationErrs := validateIllegalMapKeysObject(mapValue, yamlData)
+			objectValidationErrs := validateIllegalMapKeysObject(mapValue, keyName, yamlData)
 			errs = append(errs, objectValidationErrs...)
 		}
-	}
+
+		// the parameters and results of methods may contain maps
+		if isInterface(value) {
+			interfaceData := map[interface{}]interface{}(value.(interfaceDeclaration))
+			interfaceValidationErrs := validateIllegalMapKeysObject(interfaceData, keyName, yamlData)
+			errs = append(errs, interfaceValidationErrs...)
+		}
+	})
 
 	return
 }
 
-func validateIllegalMapKeysObject(yamlObjectData, yamlData map[interface{}]interface{}) (errs []error) {
-	for _, value := range yamlObjectData {
+func validateIllegalMapKeysObject(
+	yamlObjectData map[interface{}]interface{},
+	objectName string,
+	yamlData map[interface{}]interface{},
+) (errs []error) {
+	rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+		if isMap(value) {
+			mapValue := value.(map[interface{}]interface{})
+			objectValidationErrs := validateIllegalMapKeysObject(mapValue, declarationPathOf(keyName, objectName), yamlData)
+			errs = append(errs, objectValidationErrs...)
+			return
+		}
+
+		// embedded fields cannot contain maps
+		if isEmbeddedField(value) {
+			return
+		}
+
 		valueString := fmt.Sprintf("%v", value)
 		illegalMapKeys := findIllegalMapKeys(valueString, yamlData)
 		for _, illegalMapKey := range illegalMapKeys {
-			errs = append(errs, newValidationErrorInvalidMapKey(illegalMapKey, valueString))
+			errs = append(errs, newValidationErrorInvalidMapKey(illegalMapKey, valueString, keyName, objectName))
 		}
-	}
+	})
 	return
 }
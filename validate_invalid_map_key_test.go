@@ -4,19 +4,20 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"go/ast"
-	"go/parser"
-	"go/token"
 )
 
 func TestValidateYamlDataInvalidMapKey(t *testing.T) {
 	t.Run("should not fail on usage of valid map keys", func(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"foo": "string",
+			"bar": "[]int",
 			"bal": "map[foo]int",
 			"baz": map[interface{}]interface{}{
 				"bal": "map[foo]int",
 				"ban": "map[[2]foo]int",
+				"bam": "map[*bar]int",
+				"buf": "map[chan bar]int",
+				"bun": "map[interface{}]int",
 			},
 		}
 
@@ -29,10 +30,10 @@ func TestValidateYamlDataInvalidMapKey(t *testing.T) {
 		assert.Empty(t, redundantErrors)
 	})
 
-	t.Run("should fail on usage of reference type directly as map key", func(t *testing.T) {
+	t.Run("should fail on usage of uncomparable type directly as map key", func(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"foo": "string",
-			"bar": "map[*foo]int",
+			"bar": "map[func()]int",
 			"buf": "map[map[int]bool]string",
 			"baz": map[interface{}]interface{}{
 				"ban": "map[[]foo]int",
@@ -41,9 +42,9 @@ func TestValidateYamlDataInvalidMapKey(t *testing.T) {
 
 		actualErrors := logicalValidation(data)
 		expectedErrors := []error{
-			newValidationErrorInvalidMapKey("*foo", "map[*foo]int"),
-			newValidationErrorInvalidMapKey("map[int]bool", "map[map[int]bool]string"),
-			newValidationErrorInvalidMapKey("[]foo", "map[[]foo]int"),
+			newValidationErrorInvalidMapKey("func()", "map[func()]int", "bar", "root"),
+			newValidationErrorInvalidMapKey("map[int]bool", "map[map[int]bool]string", "buf", "root"),
+			newValidationErrorInvalidMapKey("[]foo", "map[[]foo]int", "ban", "baz"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -52,10 +53,10 @@ func TestValidateYamlDataInvalidMapKey(t *testing.T) {
 		assert.Empty(t, redundantErrors)
 	})
 
-	t.Run("should fail on usage of reference type as map key", func(t *testing.T) {
+	t.Run("should fail on usage of uncomparable type as map key", func(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"foo":  "[]string",
-			"ban":  "*int",
+			"ban":  "func(int)",
 			"bunt": "map[int]string",
 			"bar":  "map[foo]int",
 			"baz": map[interface{}]interface{}{
@@ -66,9 +67,9 @@ func TestValidateYamlDataInvalidMapKey(t *testing.T) {
 
 		actualErrors := logicalValidation(data)
 		expectedErrors := []error{
-			newValidationErrorInvalidMapKey("foo", "map[foo]int"),
-			newValidationErrorInvalidMapKey("ban", "map[ban]int"),
-			newValidationErrorInvalidMapKey("bunt", "map[bunt]int"),
+			newValidationErrorInvalidMapKey("foo", "map[foo]int", "bar", "root"),
+			newValidationErrorInvalidMapKey("ban", "map[ban]int", "bal", "baz"),
+			newValidationErrorInvalidMapKey("bunt", "map[bunt]int", "buf", "baz"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -77,7 +78,7 @@ func TestValidateYamlDataInvalidMapKey(t *testing.T) {
 		assert.Empty(t, redundantErrors)
 	})
 
-	t.Run("should fail on usage of reference type as map key in nested map", func(t *testing.T) {
+	t.Run("should fail on usage of uncomparable type as map key in nested map", func(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"foo": "[]string",
 			"bar": "map[int]map[foo]int",
@@ -88,8 +89,154 @@ func TestValidateYamlDataInvalidMapKey(t *testing.T) {
 
 		actualErrors := logicalValidation(data)
 		expectedErrors := []error{
-			newValidationErrorInvalidMapKey("foo", "map[int]map[foo]int"),
-			newValidationErrorInvalidMapKey("bar", "map[bar]int"),
+			newValidationErrorInvalidMapKey("foo", "map[int]map[foo]int", "bar", "root"),
+			newValidationErrorInvalidMapKey("bar", "map[bar]int", "bal", "baz"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on usage of uncomparable types as map key in nested objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": map[interface{}]interface{}{
+					"baz": "[]int",
+				},
+			},
+			"ban": map[interface{}]interface{}{
+				"bam": map[interface{}]interface{}{
+					"bal": "map[foo]int",
+				},
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidMapKey("foo", "map[foo]int", "bal", "ban.bam"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should consider embedded fields of map keys", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"ids": "[]int",
+			},
+			"bar": map[interface{}]interface{}{
+				"foo": nil,
+			},
+			"baz": map[interface{}]interface{}{
+				"*foo": nil,
+			},
+			"ban": "map[bar]int",
+			"bam": "map[baz]int",
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidMapKey("bar", "map[bar]int", "ban", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should treat interfaces as comparable and check map keys of methods", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": interfaceDeclaration{
+				"Get": "func(m map[[]int]string) map[foo]int",
+			},
+			"bar": "map[foo]int",
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidMapKey("[]int", "func(m map[[]int]string) map[foo]int", "Get", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on funcs but not on chans as map keys", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "func(int) string",
+			"bar": "chan<- foo",
+			"baz": map[interface{}]interface{}{
+				"ban": "map[foo]int",
+				"bam": "map[bar]int",
+				"bal": "map[<-chan foo]int",
+				"lan": "map[struct{ a func() }]int",
+				"kan": "map[[2]func(...int)]int",
+				"fan": "func(map[func()]int) chan map[chan int]int",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidMapKey("foo", "map[foo]int", "ban", "baz"),
+			newValidationErrorInvalidMapKey("struct{ a func() }", "map[struct{ a func() }]int", "lan", "baz"),
+			newValidationErrorInvalidMapKey("[2]func(...int)", "map[[2]func(...int)]int", "kan", "baz"),
+			newValidationErrorInvalidMapKey("func()", "func(map[func()]int) chan map[chan int]int", "fan", "baz"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should see through aliases", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": aliasDeclaration("[]int"),
+			"bar": aliasDeclaration("foo"),
+			"baz": aliasDeclaration("string"),
+			"ban": "map[bar]int",
+			"bam": "map[baz]int",
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidMapKey("bar", "map[bar]int", "ban", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should respect the declared comparability of external types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": importSection{
+				"time": map[interface{}]interface{}{
+					"types": map[interface{}]interface{}{"Time": "comparable"},
+				},
+				"bytes": map[interface{}]interface{}{
+					"types": map[interface{}]interface{}{"Buffer": "incomparable"},
+				},
+			},
+			"foo": map[interface{}]interface{}{
+				"byTime":    "map[time.Time]int",
+				"byBuffer":  "map[bytes.Buffer]int",
+				"byPointer": "map[*bytes.Buffer]int",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidMapKey("bytes.Buffer", "map[bytes.Buffer]int", "byBuffer", "foo"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -105,23 +252,14 @@ func TestExtractMapKeys(t *testing.T) {
 		assert.Equal(t, extractMapKeys("map[*int]string"), []string{"*int"})
 		assert.Equal(t, extractMapKeys("map[[]int]string"), []string{"[]int"})
 		assert.Equal(t, extractMapKeys("map[int]map[float]map[string]bool"), []string{"int", "float", "string"})
-		assert.Equal(t, extractMapKeys("map[map[map[bool]int]string]float"), []string{"map[map[bool]int]string"})
-		assert.Equal(t, extractMapKeys("map[map[map[bool]int]string]map[map[bool]int]float"), []string{"map[map[bool]int]string", "map[bool]int"})
+		assert.Equal(t, extractMapKeys("map[map[map[bool]int]string]float"), []string{"map[map[bool]int]string", "map[bool]int", "bool"})
+		assert.Equal(t, extractMapKeys("map[map[map[bool]int]string]map[map[bool]int]float"), []string{"map[map[bool]int]string", "map[bool]int", "bool", "map[bool]int", "bool"})
 		assert.Equal(t, extractMapKeys("map[int][]map[float]int"), []string{"int", "float"})
 		assert.Equal(t, extractMapKeys("map[int][][][]map[float]int"), []string{"int", "float"})
 		assert.Equal(t, extractMapKeys("map[int]*[][]map[float]int"), []string{"int", "float"})
-	})
-}
-
-func TestExtracpMapDeclExpression(t *testing.T) {
-	t.Run("should extract map decl", func(t *testing.T) {
-		mockSrc := `
-	package main
-	type mockType map[int]string
-	`
-
-		file, _ := parser.ParseFile(token.NewFileSet(), "", mockSrc, 0)
-		assert.Equal(t, extracpMapDeclExpression(file).(*ast.MapType).Key.(*ast.Ident).Name, "int")
+		assert.Equal(t, extractMapKeys("struct{ a map[int]bool }"), []string{"int"})
+		assert.Equal(t, extractMapKeys("chan map[int]bool"), []string{"int"})
+		assert.Equal(t, extractMapKeys("func(map[int]bool) map[string]bool"), []string{"int", "string"})
 	})
 }
 
@@ -163,14 +301,24 @@ func TestContainsInvalidMapKeys(t *testing.T) {
 
 		assert.Equal(t, len(illegalMapKeys), 0)
 	})
+	t.Run("should not contain illegal map keys when pointers are used", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "[]string",
+			"bar": "map[*foo]int",
+		}
+
+		illegalMapKeys := findIllegalMapKeys("map[*foo]int", data)
+
+		assert.Equal(t, len(illegalMapKeys), 0)
+	})
 	t.Run("should contain illegal map keys (1/3)", func(t *testing.T) {
 		data := map[interface{}]interface{}{
-			"bar": "map[*string]int",
+			"bar": "map[[]string]int",
 		}
 
-		illegalMapKeys := findIllegalMapKeys("map[*string]int", data)
+		illegalMapKeys := findIllegalMapKeys("map[[]string]int", data)
 
-		assert.Equal(t, illegalMapKeys, []string{"*string"})
+		assert.Equal(t, illegalMapKeys, []string{"[]string"})
 	})
 	t.Run("should contain illegal map keys (2/3)", func(t *testing.T) {
 		data := map[interface{}]interface{}{
@@ -186,7 +334,7 @@ func TestContainsInvalidMapKeys(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"bar": "map[foo]int",
 			"foo": map[interface{}]interface{}{
-				"bal": "*int",
+				"bal": "[]int",
 			},
 		}
 
@@ -205,21 +353,21 @@ func TestContainsInvalidMapKeys(t *testing.T) {
 	})
 	t.Run("should contain illegal nested map keys (2/3)", func(t *testing.T) {
 		data := map[interface{}]interface{}{
-			"bar": "map[*string]map[foo]bool",
+			"bar": "map[[]string]map[foo]bool",
 			"foo": map[interface{}]interface{}{
-				"bal": "*int",
+				"bal": "map[int]int",
 			},
 		}
 
-		illegalMapKeys := findIllegalMapKeys("map[*string]map[foo]bool", data)
+		illegalMapKeys := findIllegalMapKeys("map[[]string]map[foo]bool", data)
 
-		assert.Equal(t, illegalMapKeys, []string{"*string", "foo"})
+		assert.Equal(t, illegalMapKeys, []string{"[]string", "foo"})
 	})
 	t.Run("should contain illegal nested map keys (3/3)", func(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"bar": "map[ban]bool",
 			"foo": map[interface{}]interface{}{
-				"bal": "*int",
+				"bal": "func() int",
 			},
 			"ban": map[interface{}]interface{}{
 				"bunt": "foo",
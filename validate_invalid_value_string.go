@@ -2,23 +2,24 @@ package yamltostruct
 
 import (
 	"fmt"
-	"go/parser"
-	"go/token"
+	"go/ast"
 )
 
+// values are spliced into the generated source code, so they have to
+// consist of exactly one type expression and nothing else
 func isValidValueString(value string) bool {
-	sourceCodeMock := `
-	package main
-	type a ` + value
+	_, err := parseTypeExpr(value)
+	return err == nil
+}
 
-	_, err := parser.ParseFile(token.NewFileSet(), "", sourceCodeMock, 0)
+// values of constants and variables are spliced into the generated source code as well
+func isValidValueExpr(value string) bool {
+	_, err := parseValueExpr(value)
 	return err == nil
 }
 
 func validateInvalidValueString(yamlData map[interface{}]interface{}) (errs []error) {
-	for key, value := range yamlData {
-		keyName := fmt.Sprintf("%v", key)
-
+	rangeInAlphabeticalOrder(yamlData, func(keyName string, value interface{}) {
 		if isString(value) {
 			valueString := fmt.Sprintf("%v", value)
 			if !isValidValueString(valueString) {
@@ -31,19 +32,94 @@ func validateInvalidValueString(yamlData map[interface{}]interface{}) (errs []er
 			objectValidationErrs := validateInvalidValueStringObject(mapValue, keyName)
 			errs = append(errs, objectValidationErrs...)
 		}
+
+		if isInterface(value) {
+			interfaceValidationErrs := validateInvalidValueStringInterface(value.(interfaceDeclaration), keyName)
+			errs = append(errs, interfaceValidationErrs...)
+		}
+
+		// constraints of type parameters are spliced into the type declaration
+		for _, param := range typeParamsOf(yamlData, keyName) {
+			if !isConstraintExpr(param.constraint) {
+				errs = append(errs, newValidationErrorInvalidValueString(param.constraintString, param.name, keyName))
+			}
+		}
+	})
+
+	for _, externalPkg := range externalPackagesOf(yamlData) {
+		if !isValidImportPath(externalPkg.path) {
+			errs = append(errs, newValidationErrorInvalidValueString(externalPkg.path, importPathKey, declarationPathOf(externalPkg.name, importSectionKey)))
+		}
+	}
+
+	rangeValueSections(yamlData, func(sectionKey string, section valueSection) {
+		sectionValidationErrs := validateInvalidValueStringSection(section, sectionKey)
+		errs = append(errs, sectionValidationErrs...)
+	})
+
+	return
+}
+
+// the types of constants and variables have to be type expressions, their values value expressions
+func validateInvalidValueStringSection(section valueSection, sectionKey string) (errs []error) {
+	rangeInAlphabeticalOrder(section, func(keyName string, value interface{}) {
+		typeString, valueString, ok := valueDeclarationOf(sectionKey, value)
+		if !ok {
+			return
+		}
+		if typeString != "" && !isValidValueString(typeString) {
+			errs = append(errs, newValidationErrorInvalidValueString(typeString, keyName, sectionKey))
+		}
+		if valueString != "" && !isValidValueExpr(valueString) {
+			errs = append(errs, newValidationErrorInvalidValueString(valueString, keyName, sectionKey))
+		}
+	})
+
+	return
+}
+
+// methods have to be declared with func types ("func(p []byte) (int, error)")
+func isValidMethodSignature(value string) bool {
+	typeExpr, err := parseTypeExpr(value)
+	if err != nil {
+		return false
 	}
+	_, isFuncType := typeExpr.(*ast.FuncType)
+	return isFuncType
+}
+
+func validateInvalidValueStringInterface(yamlInterfaceData interfaceDeclaration, interfaceName string) (errs []error) {
+	rangeInAlphabeticalOrder(yamlInterfaceData, func(keyName string, value interface{}) {
+		if isEmbeddedField(value) {
+			return
+		}
 
+		valueString := fmt.Sprintf("%v", value)
+		if !isValidMethodSignature(valueString) {
+			errs = append(errs, newValidationErrorInvalidValueString(valueString, keyName, interfaceName))
+		}
+	})
 	return
 }
 
 func validateInvalidValueStringObject(yamlObjectData map[interface{}]interface{}, objectName string) (errs []error) {
-	for key, value := range yamlObjectData {
-		keyName := fmt.Sprintf("%v", key)
+	rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+		if isEmbeddedField(value) {
+			return
+		}
+
+		if isMap(value) {
+			mapValue := value.(map[interface{}]interface{})
+			objectValidationErrs := validateInvalidValueStringObject(mapValue, declarationPathOf(keyName, objectName))
+			errs = append(errs, objectValidationErrs...)
+			return
+		}
+
 		valueString := fmt.Sprintf("%v", value)
 
 		if !isValidValueString(valueString) {
 			errs = append(errs, newValidationErrorInvalidValueString(valueString, keyName, objectName))
 		}
-	}
+	})
 	return
 }
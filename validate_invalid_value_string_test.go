@@ -65,6 +65,140 @@ func TestValidateYamlInvalidValueString(t *testing.T) {
 		assert.Empty(t, missingErrors)
 		assert.Empty(t, redundantErrors)
 	})
+
+	t.Run("should fail on values injecting code", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"a": "int; func init() { panic(1) }",
+			"baz": map[interface{}]interface{}{
+				"b": "int\ntype evil struct{}",
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidValueString("int; func init() { panic(1) }", "a", "root"),
+			newValidationErrorInvalidValueString("int\ntype evil struct{}", "b", "baz"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on invalid value strings in nested objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": map[interface{}]interface{}{
+					"baz": "[]in[t",
+					"ban": map[interface{}]interface{}{
+						"bam": "int",
+					},
+				},
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidValueString("[]in[t", "baz", "foo.bar"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on methods which are not declared with func types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": interfaceDeclaration{
+				"Read":  "func(p []byte) (n int, err error)",
+				"Close": "func() error",
+				"Len":   "int",
+				"Do":    "func(f func()) func() int",
+				"Bad":   "func() {}",
+				"bar":   nil,
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidValueString("int", "Len", "foo"),
+			newValidationErrorInvalidValueString("func() {}", "Bad", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on invalid types and values of constants and variables", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": valueSection{
+				"foo": "maxPlayers / 2",
+				"bar": "1; func init() {}",
+				"baz": map[interface{}]interface{}{"type": "[]in[t", "value": 1},
+				"ban": "func() int { return 1 }()",
+			},
+			"var": valueSection{
+				"foo": "[]int",
+				"bar": "1 + 1",
+				"baz": map[interface{}]interface{}{"type": "map[string]int", "value": "map[string]int{}"},
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidValueString("1; func init() {}", "bar", "const"),
+			newValidationErrorInvalidValueString("[]in[t", "baz", "const"),
+			newValidationErrorInvalidValueString("func() int { return 1 }()", "ban", "const"),
+			newValidationErrorInvalidValueString("1 + 1", "bar", "var"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on invalid constraints of type parameters", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"foo", "[T any, U [bar()]int]"}: "[]T",
+			genericTypeName{"baz", "[T ~int | ~string]"}:    "[]T",
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidValueString("[bar()]int", "U", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on invalid import paths", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": importSection{
+				"uuid": map[interface{}]interface{}{"path": "github.com/google/uuid"},
+				"evil": map[interface{}]interface{}{"path": "time\"; func init() {}; \""},
+				"http": map[interface{}]interface{}{"path": "net http"},
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorInvalidValueString("time\"; func init() {}; \"", "path", "import.evil"),
+			newValidationErrorInvalidValueString("net http", "path", "import.http"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
 }
 
 func TestIsValidValueString(t *testing.T) {
@@ -99,4 +233,15 @@ func TestIsValidValueString(t *testing.T) {
 		assert.Equal(t, isValidValueString("foo"), true)
 		assert.Equal(t, isValidValueString("bar"), true)
 	})
+
+	t.Run("is not valid if more than a type expression is given", func(t *testing.T) {
+		assert.Equal(t, isValidValueString("int; func init() { panic(1) }"), false)
+		assert.Equal(t, isValidValueString("int\ntype evil struct{}"), false)
+		assert.Equal(t, isValidValueString("int;"), false)
+		assert.Equal(t, isValidValueString("struct{}\nfunc init() {}"), false)
+		assert.Equal(t, isValidValueString("func() { panic(1) }"), false)
+		assert.Equal(t, isValidValueString("[]int{}"), false)
+		assert.Equal(t, isValidValueString("1+2"), false)
+		assert.Equal(t, isValidValueString("[func() int { return 1 }()]int"), false)
+	})
 }
@@ -0,0 +1,126 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+)
+
+// returns errors for fields which are promoted from more than one embedded type on the same depth;
+// selecting such a field is ambiguous (https://golang.org/ref/spec#Selectors)
+func validatePromotedFieldConflicts(yamlData map[interface{}]interface{}) (errs []error) {
+	rangeObjects(yamlData, func(objectName string, yamlObjectData map[interface{}]interface{}) {
+		objectErrs := findPromotedFieldConflicts(yamlObjectData, objectName, yamlData)
+		errs = append(errs, objectErrs...)
+	})
+
+	return
+}
+
+// the fields are looked up depth by depth like go/types does it;
+// names found on a shallower depth shadow the ones found deeper
+func findPromotedFieldConflicts(
+	yamlObjectData map[interface{}]interface{},
+	objectName string,
+	yamlData map[interface{}]interface{},
+) (errs []error) {
+	shadowedNames := make(map[string]bool)
+	embeddedTypes := collectFieldNames(yamlObjectData, func(fieldName string) {
+		shadowedNames[fieldName] = true
+	})
+
+	// a type which was looked up on a shallower depth shadows itself on deeper ones
+	seenTypes := make(map[string]bool)
+
+	for len(embeddedTypes) > 0 {
+		// the embedded types each field of the current depth is promoted from
+		sourcesOf := make(map[string][]string)
+		var nextEmbeddedTypes []string
+
+		for _, typeName := range embeddedTypes {
+			if seenTypes[typeName] {
+				continue
+			}
+			seenTypes[typeName] = true
+
+			structData := resolveStructType(typeName, yamlData)
+			if structData == nil {
+				continue
+			}
+
+			// a type embedded more than once on the same depth promotes all its fields more than once
+			for i := 0; i < countOf(typeName, embeddedTypes); i++ {
+				nestedEmbeddedTypes := collectFieldNames(structData, func(fieldName string) {
+					sourcesOf[fieldName] = append(sourcesOf[fieldName], typeName)
+				})
+				nextEmbeddedTypes = append(nextEmbeddedTypes, nestedEmbeddedTypes...)
+			}
+		}
+
+		var fieldNames []string
+		for fieldName := range sourcesOf {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+
+		for _, fieldName := range fieldNames {
+			if shadowedNames[fieldName] {
+				continue
+			}
+			shadowedNames[fieldName] = true
+			if sources := sourcesOf[fieldName]; len(sources) > 1 {
+				sort.Strings(sources)
+				errs = append(errs, newValidationErrorPromotedFieldConflict(fieldName, objectName, sources))
+			}
+		}
+
+		embeddedTypes = nextEmbeddedTypes
+	}
+
+	return
+}
+
+// calls fn with the name of every field of the object (embedded fields are named after their type)
+// and returns the types which are embedded in it
+func collectFieldNames(yamlObjectData map[interface{}]interface{}, fn func(fieldName string)) (embeddedTypes []string) {
+	rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+		if isEmbeddedField(value) {
+			typeName := embeddedTypeName(keyName)
+			embeddedTypes = append(embeddedTypes, typeName)
+			fn(typeName)
+			return
+		}
+		fn(keyName)
+	})
+	return
+}
+
+// returns the object the named type is declared as, following named types declared
+// by other named types ("foo: bar"); returns nil if the type is no struct type
+func resolveStructType(typeName string, yamlData map[interface{}]interface{}) map[interface{}]interface{} {
+	// every declaration is followed at most once so cyclic declarations end
+	for i := 0; i < len(yamlData); i++ {
+		value, ok := declarationOf(yamlData, typeName)
+		if !ok {
+			return nil
+		}
+		if isMap(value) {
+			return value.(map[interface{}]interface{})
+		}
+		valueString := fmt.Sprintf("%v", value)
+		if !isString(value) || !token.IsIdentifier(valueString) {
+			return nil
+		}
+		typeName = valueString
+	}
+	return nil
+}
+
+func countOf(str string, strs []string) (count int) {
+	for _, s := range strs {
+		if s == str {
+			count++
+		}
+	}
+	return
+}
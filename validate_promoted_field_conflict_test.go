@@ -0,0 +1,161 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYamlDataPromotedFieldConflict(t *testing.T) {
+	t.Run("should not fail on unambiguous promoted fields", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"audit": map[interface{}]interface{}{
+				"createdAt": "int64",
+				"id":        "string",
+			},
+			"base": map[interface{}]interface{}{
+				"id": "string",
+			},
+			"user": map[interface{}]interface{}{
+				"audit": nil,
+				"*base": nil,
+				// declared fields shadow promoted ones
+				"id": "int",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on fields promoted by several embedded types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"audit": map[interface{}]interface{}{
+				"createdAt": "int64",
+				"id":        "string",
+			},
+			"base": map[interface{}]interface{}{
+				"id":    "string",
+				"audit": "int",
+			},
+			"user": map[interface{}]interface{}{
+				"audit": nil,
+				"*base": nil,
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		// "base.audit" is shadowed by the embedded field "audit"
+		expectedErrors := []error{
+			newValidationErrorPromotedFieldConflict("id", "user", []string{"audit", "base"}),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should prefer fields promoted from shallower depths", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"id": "string",
+			},
+			"bar": map[interface{}]interface{}{
+				"foo": nil,
+			},
+			"baz": map[interface{}]interface{}{
+				"id": "string",
+			},
+			"ban": map[interface{}]interface{}{
+				"bar": nil,
+				"baz": nil,
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on fields promoted by the same type on several paths", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"id": "string",
+			},
+			"bar": map[interface{}]interface{}{
+				"foo": nil,
+			},
+			"baz": map[interface{}]interface{}{
+				"*foo": nil,
+			},
+			"ban": map[interface{}]interface{}{
+				"bar":  nil,
+				"*baz": nil,
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorPromotedFieldConflict("foo", "ban", []string{"bar", "baz"}),
+			newValidationErrorPromotedFieldConflict("id", "ban", []string{"foo", "foo"}),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should follow named types and nested objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"id": "string",
+			},
+			"fooAlias": "foo",
+			"bar": map[interface{}]interface{}{
+				"id": "string",
+			},
+			"ban": map[interface{}]interface{}{
+				"bam": map[interface{}]interface{}{
+					"fooAlias": nil,
+					"bar":      nil,
+				},
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorPromotedFieldConflict("id", "ban.bam", []string{"bar", "fooAlias"}),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should terminate on types embedding each other", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"*bar": nil,
+			},
+			"bar": map[interface{}]interface{}{
+				"*foo": nil,
+			},
+		}
+
+		actualErrors := validatePromotedFieldConflicts(data)
+
+		assert.Empty(t, actualErrors)
+	})
+}
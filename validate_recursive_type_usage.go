@@ -1,15 +1,76 @@
 package yamltostruct
 
+import (
+	"strings"
+)
+
+// returns one error per distinct cycle of types that contain each other by value
+// or of aliases that refer to each other in any way;
+// every cycle is reported only once, no matter how many paths lead into it
 func validateRecursiveTypeUsage(yamlData map[interface{}]interface{}) (errs []error) {
 	pathBuilder := newPathBuilder(yamlData)
 
 	pathBuilder.build(declarationPath{}, "", yamlData, fieldLevelZero)
 
+	rangeTypeDeclarations(yamlData, func(keyName string, value interface{}) {
+		if isAlias(value) {
+			pathBuilder.buildAliasPath(declarationPath{}, keyName, value)
+		}
+	})
+
+	reportedCycles := make(map[string]bool)
+
 	for _, path := range pathBuilder.paths {
-		if path.closureKind == pathClosureKindRecursiveness {
-			errs = append(errs, newValidationErrorRecursiveTypeUsage(path.joinedNames()))
+		if path.closureKind != pathClosureKindRecursiveness {
+			continue
+		}
+
+		cycle := canonicalCycle(path.joinedNames())
+		cycleIdentifier := strings.Join(cycle, "->")
+		if reportedCycles[cycleIdentifier] {
+			continue
 		}
+		reportedCycles[cycleIdentifier] = true
+
+		errs = append(errs, newValidationErrorRecursiveTypeUsage(cycle))
 	}
 
 	return
 }
+
+// "bar.foo" => "bar", "bar" => "bar"
+func typeNameOfJoinedName(joinedName string) string {
+	return strings.SplitN(joinedName, ".", 2)[0]
+}
+
+// cuts off the part of a recursive path that leads into the cycle and rotates
+// the cycle so it starts at the alphabetically first type
+// []string{"baf.bal", "bar.foo", "baz.ban", "bar"} => []string{"bar.foo", "baz.ban", "bar"}
+// []string{"baz.ban", "bar.foo", "baz"} => []string{"bar.foo", "baz.ban", "bar"}
+func canonicalCycle(joinedNames []string) []string {
+	// the last name is the type that closed the cycle
+	recurringTypeName := joinedNames[len(joinedNames)-1]
+
+	var cycleStart int
+	for i, joinedName := range joinedNames {
+		if typeNameOfJoinedName(joinedName) == recurringTypeName {
+			cycleStart = i
+			break
+		}
+	}
+	cycle := joinedNames[cycleStart : len(joinedNames)-1]
+
+	var canonicalStart int
+	for i, joinedName := range cycle {
+		if typeNameOfJoinedName(joinedName) < typeNameOfJoinedName(cycle[canonicalStart]) {
+			canonicalStart = i
+		}
+	}
+
+	var canonicalCycle []string
+	canonicalCycle = append(canonicalCycle, cycle[canonicalStart:]...)
+	canonicalCycle = append(canonicalCycle, cycle[:canonicalStart]...)
+	canonicalCycle = append(canonicalCycle, typeNameOfJoinedName(canonicalCycle[0]))
+
+	return canonicalCycle
+}
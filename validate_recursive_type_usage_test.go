@@ -62,7 +62,6 @@ func TestValidateYamlRecursiveTypeUsage(t *testing.T) {
 		actualErrors := logicalValidation(data)
 		expectedErrors := []error{
 			newValidationErrorRecursiveTypeUsage([]string{"bar.foo", "baz.ban", "bar"}),
-			newValidationErrorRecursiveTypeUsage([]string{"baz.ban", "bar.foo", "baz"}),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -87,8 +86,6 @@ func TestValidateYamlRecursiveTypeUsage(t *testing.T) {
 		actualErrors := logicalValidation(data)
 		expectedErrors := []error{
 			newValidationErrorRecursiveTypeUsage([]string{"bam.baf", "baz.ban", "bar.foo", "bam"}),
-			newValidationErrorRecursiveTypeUsage([]string{"baz.ban", "bar.foo", "bam.baf", "baz"}),
-			newValidationErrorRecursiveTypeUsage([]string{"bar.foo", "bam.baf", "baz.ban", "bar"}),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -160,4 +157,226 @@ func TestValidateYamlRecursiveTypeUsage(t *testing.T) {
 		assert.Empty(t, missingErrors)
 		assert.Empty(t, redundantErrors)
 	})
+	t.Run("should report cycle only once when entered by several fields", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"a": map[interface{}]interface{}{
+				"next": "b",
+			},
+			"b": map[interface{}]interface{}{
+				"link": "c",
+			},
+			"c": map[interface{}]interface{}{
+				"back": "a",
+			},
+			"person": map[interface{}]interface{}{
+				"first":  "b",
+				"second": "c",
+			},
+			"team": "[2]person",
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorRecursiveTypeUsage([]string{"a.next", "b.link", "c.back", "a"}),
+		}
+
+		assert.Equal(t, expectedErrors, actualErrors)
+	})
+
+	t.Run("should report distinct cycles separately", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "bar",
+			"bar": "foo",
+			"baz": map[interface{}]interface{}{
+				"ban": "baz",
+				"bam": "foo",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorRecursiveTypeUsage([]string{"bar", "foo", "bar"}),
+			newValidationErrorRecursiveTypeUsage([]string{"baz.ban", "baz"}),
+		}
+
+		assert.Equal(t, expectedErrors, actualErrors)
+	})
+	t.Run("should detect recursion of types with underscores and digits", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"user_1": map[interface{}]interface{}{
+				"next": "[2]user_2",
+			},
+			"user_2": map[interface{}]interface{}{
+				"back": "user_1",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorRecursiveTypeUsage([]string{"user_1.next", "user_2.back", "user_1"}),
+		}
+
+		assert.Equal(t, expectedErrors, actualErrors)
+	})
+
+	t.Run("should fail on usage of recursive types in nested objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"bar": map[interface{}]interface{}{
+				"foo": map[interface{}]interface{}{
+					"ban": "baz",
+				},
+			},
+			"baz": map[interface{}]interface{}{
+				"bam": map[interface{}]interface{}{
+					"bal": map[interface{}]interface{}{
+						"lan": "bar",
+					},
+					"kan": "*bar",
+				},
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorRecursiveTypeUsage([]string{"bar.foo.ban", "baz.bam.bal.lan", "bar"}),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should not trigger recursive errors when nested objects have the same name as their parent", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"bar": map[interface{}]interface{}{
+				"bar": map[interface{}]interface{}{
+					"bar": "int",
+				},
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on types embedding each other", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"bar": map[interface{}]interface{}{
+				"baz": nil,
+			},
+			"baz": map[interface{}]interface{}{
+				"bar": nil,
+			},
+			"ban": map[interface{}]interface{}{
+				"*ban": nil,
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorRecursiveTypeUsage([]string{"bar.baz", "baz.bar", "bar"}),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should not trigger recursive errors when funcs or chans are used", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "func(foo) foo",
+			"bar": "chan bar",
+			"baz": map[interface{}]interface{}{
+				"ban": "func(...baz) (baz, error)",
+				"bam": "<-chan baz",
+				"bal": "[2]chan<- baz",
+				"lan": "struct{ a func() baz }",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on usage of recursive types in anonymous structs", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "struct{ a int; b [2]foo }",
+			"bar": map[interface{}]interface{}{
+				"ban": "struct{ a *bar; b baz }",
+			},
+			"baz": "[3]bar",
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorRecursiveTypeUsage([]string{"foo", "foo"}),
+			newValidationErrorRecursiveTypeUsage([]string{"bar.ban", "baz", "bar"}),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on aliases referring to themselves", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": aliasDeclaration("*foo"),
+			"bar": aliasDeclaration("[]baz"),
+			"baz": aliasDeclaration("func() bar"),
+			"ban": aliasDeclaration("[2]bam"),
+			"bam": map[interface{}]interface{}{
+				"bal": "ban",
+			},
+			// defined types may refer to themselves through aliases
+			"lan": aliasDeclaration("*kan"),
+			"kan": map[interface{}]interface{}{
+				"next": "lan",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorRecursiveTypeUsage([]string{"foo", "foo"}),
+			newValidationErrorRecursiveTypeUsage([]string{"bar", "baz", "bar"}),
+			newValidationErrorRecursiveTypeUsage([]string{"bam.bal", "ban", "bam"}),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
+
+func TestCanonicalCycle(t *testing.T) {
+	t.Run("should cut off the path leading into the cycle", func(t *testing.T) {
+		assert.Equal(t,
+			[]string{"bar.foo", "baz.ban", "bar"},
+			canonicalCycle([]string{"baf.bal", "bar.foo", "baz.ban", "bar"}),
+		)
+	})
+
+	t.Run("should rotate cycle to alphabetically first type", func(t *testing.T) {
+		assert.Equal(t,
+			[]string{"bar.foo", "baz.ban", "bar"},
+			canonicalCycle([]string{"baz.ban", "bar.foo", "baz"}),
+		)
+		assert.Equal(t,
+			[]string{"bar", "foo", "bar"},
+			canonicalCycle([]string{"foo", "bar", "foo"}),
+		)
+	})
 }
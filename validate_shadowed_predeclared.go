@@ -0,0 +1,51 @@
+package yamltostruct
+
+// all identifiers of go's universe scope
+var golangPredeclaredIdentifiers = []string{
+	// types
+	"any", "bool", "byte", "comparable", "complex64", "complex128", "error", "float32", "float64",
+	"int", "int8", "int16", "int32", "int64", "rune", "string",
+	"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+	// constants
+	"true", "false", "iota",
+	// zero value
+	"nil",
+	// functions
+	"append", "cap", "clear", "close", "complex", "copy", "delete", "imag", "len", "make",
+	"max", "min", "new", "panic", "print", "println", "real", "recover",
+}
+
+func isPredeclaredIdentifier(name string) bool {
+	for _, predeclaredIdentifier := range golangPredeclaredIdentifiers {
+		if predeclaredIdentifier == name {
+			return true
+		}
+	}
+	return false
+}
+
+// returns errors if declared types, type parameters, constants or variables would shadow predeclared identifiers ("int: string"),
+// which would silently change the meaning of every usage of that identifier
+func validateShadowedPredeclared(yamlData map[interface{}]interface{}) (errs []error) {
+	rangeTypeDeclarations(yamlData, func(keyName string, _ interface{}) {
+		if isPredeclaredIdentifier(keyName) {
+			errs = append(errs, newValidationErrorShadowedPredeclared(keyName, "root"))
+		}
+		// type parameters shadow predeclared identifiers within their type ("list[int any]")
+		for _, param := range typeParamsOf(yamlData, keyName) {
+			if isPredeclaredIdentifier(param.name) {
+				errs = append(errs, newValidationErrorShadowedPredeclared(param.name, keyName))
+			}
+		}
+	})
+
+	rangeValueSections(yamlData, func(sectionKey string, section valueSection) {
+		rangeInAlphabeticalOrder(section, func(keyName string, _ interface{}) {
+			if isPredeclaredIdentifier(keyName) {
+				errs = append(errs, newValidationErrorShadowedPredeclared(keyName, sectionKey))
+			}
+		})
+	})
+
+	return
+}
@@ -0,0 +1,95 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYamlDataShadowedPredeclared(t *testing.T) {
+	t.Run("should not fail on declarations that do not shadow predeclared identifiers", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "int",
+			"baz": map[interface{}]interface{}{
+				"len":    "int",
+				"string": "string",
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on declarations shadowing predeclared identifiers", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"int":    "string",
+			"string": "int",
+			"error":  "string",
+			"any":    "int",
+			"true":   "bool",
+			"nil":    "int",
+			"len":    "int",
+			"foo":    "int",
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorShadowedPredeclared("int", "root"),
+			newValidationErrorShadowedPredeclared("string", "root"),
+			newValidationErrorShadowedPredeclared("error", "root"),
+			newValidationErrorShadowedPredeclared("any", "root"),
+			newValidationErrorShadowedPredeclared("true", "root"),
+			newValidationErrorShadowedPredeclared("nil", "root"),
+			newValidationErrorShadowedPredeclared("len", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on constants and variables shadowing predeclared identifiers", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": valueSection{
+				"true": 1,
+				"foo":  2,
+			},
+			"var": valueSection{
+				"len": "int",
+			},
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorShadowedPredeclared("true", "const"),
+			newValidationErrorShadowedPredeclared("len", "var"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on type parameters shadowing predeclared identifiers", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"foo", "[int any, T any]"}: "[]T",
+		}
+
+		actualErrors := syntacticalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorShadowedPredeclared("int", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
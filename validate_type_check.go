@@ -0,0 +1,48 @@
+package yamltostruct
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// type-checks the declarations with go/types as a last line of defense;
+// this catches everything the other validators do not cover (e.g. "[-1]int")
+func validateTypeCheck(yamlData map[interface{}]interface{}) (errs []error) {
+	sw := writeSourceCode(yamlData, nil, nil, nil, false)
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", sw.sourceCode, 0)
+	if err != nil {
+		// the syntactical validation makes sure this does not happen
+		return
+	}
+
+	reported := make(map[sourceOrigin]bool)
+	conf := types.Config{
+		Importer: newExternalImporter(yamlData),
+		Error: func(err error) {
+			typeErr, ok := err.(types.Error)
+			// continuation lines of a previous error (e.g. "\tfoo refers to bar") start with a tab
+			if !ok || strings.HasPrefix(typeErr.Msg, "\t") {
+				return
+			}
+			origin, ok := sw.origins[fileSet.Position(typeErr.Pos).Line]
+			// errors in generated code which belongs to no key (e.g. the imports) are reported on root level
+			if !ok {
+				origin = sourceOrigin{keyName: "root", parentItemName: "root"}
+			}
+			// only the first error of each key is reported
+			if reported[origin] {
+				return
+			}
+			reported[origin] = true
+			errs = append(errs, newValidationErrorTypeCheck(typeErr.Msg, origin.keyName, origin.parentItemName))
+		},
+	}
+	conf.Check(mockPackageName, fileSet, []*ast.File{file}, nil)
+
+	return
+}
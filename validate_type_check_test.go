@@ -0,0 +1,150 @@
+package yamltostruct
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYamlDataTypeCheck(t *testing.T) {
+	t.Run("should not fail on valid declarations", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "[2]string",
+			"bar": "map[foo]*bar",
+			"baz": map[interface{}]interface{}{
+				"ban": "[1 << 3]foo",
+				"bam": "func(bar) chan baz",
+			},
+		}
+
+		actualErrors := validateTypeCheck(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on declarations which do not type-check", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "[-1]int",
+			"bar": "string",
+			"baz": map[interface{}]interface{}{
+				"ban": "[bar]int",
+				"bam": "[1 << 70]int",
+			},
+		}
+
+		actualErrors := validateTypeCheck(data)
+		expectedErrors := []error{
+			newValidationErrorTypeCheck("invalid array length -1 (untyped int constant)", "foo", "root"),
+			newValidationErrorTypeCheck("invalid array length bar", "ban", "baz"),
+			newValidationErrorTypeCheck("invalid array length 1 << 70 (untyped int constant 1180591620717411303424)", "bam", "baz"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should report recursive declarations once per key", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "[2]foo",
+		}
+
+		actualErrors := validateTypeCheck(data)
+
+		assert.Equal(t, 1, len(actualErrors))
+		assert.True(t, errors.Is(actualErrors[0], ErrTypeCheck))
+		assert.Equal(t, "foo", actualErrors[0].(*ValidationError).KeyName)
+	})
+
+	t.Run("should fail on declarations in nested objects which do not type-check", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": map[interface{}]interface{}{
+					"baz": "[-1]int",
+				},
+			},
+		}
+
+		actualErrors := validateTypeCheck(data)
+		expectedErrors := []error{
+			newValidationErrorTypeCheck("invalid array length -1 (untyped int constant)", "baz", "foo.bar"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on usage of interfaces embedding non-interface types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": "int",
+			},
+			// only valid as type constraint
+			"baz": interfaceDeclaration{
+				"foo": nil,
+				"Do":  "func()",
+			},
+			"ban": "[]baz",
+		}
+
+		actualErrors := validateTypeCheck(data)
+
+		assert.Equal(t, 1, len(actualErrors))
+		assert.True(t, errors.Is(actualErrors[0], ErrTypeCheck))
+		assert.Equal(t, "ban", actualErrors[0].(*ValidationError).KeyName)
+	})
+
+	t.Run("should not fail on types of packages imported under several names", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": importSection{
+				"a": map[interface{}]interface{}{
+					"path":  "example.com/x",
+					"types": map[interface{}]interface{}{"T": "comparable"},
+				},
+				"b": map[interface{}]interface{}{
+					"path":  "example.com/x",
+					"types": map[interface{}]interface{}{"U": "comparable"},
+				},
+			},
+			"foo": map[interface{}]interface{}{
+				"t": "a.T",
+				"u": "b.U",
+			},
+		}
+
+		actualErrors := validateTypeCheck(data)
+
+		assert.Empty(t, actualErrors)
+	})
+
+	t.Run("should report errors of generated code which belongs to no key on root level", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": importSection{
+				"init": map[interface{}]interface{}{
+					"path":  "example.com/x",
+					"types": map[interface{}]interface{}{"T": "comparable"},
+				},
+			},
+			"foo": "init.T",
+		}
+
+		actualErrors := validateTypeCheck(data)
+		expectedErrors := []error{
+			newValidationErrorTypeCheck("cannot import package as init - init must be a func", "root", "root"),
+			newValidationErrorTypeCheck("undefined: init", "foo", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
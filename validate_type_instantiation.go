@@ -0,0 +1,180 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// returns errors if generic types are used with the wrong number of type arguments ("list", "pair[int]"),
+// types which are not generic are instantiated ("user[int]") or type arguments do not satisfy
+// the constraints of their type parameters ("set[[]int]" with "set[T comparable]")
+func validateTypeInstantiations(yamlData map[interface{}]interface{}) (errs []error) {
+	var definedTypes []string
+	rangeTypeDeclarations(yamlData, func(keyName string, _ interface{}) {
+		definedTypes = append(definedTypes, keyName)
+	})
+	definedTypes = append(definedTypes, externalTypeNames(yamlData)...)
+
+	rangeTypeExpressions(yamlData, func(valueString, keyName, parentItemName string) {
+		typeExpr, err := parseTypeExpr(valueString)
+		if err != nil {
+			return
+		}
+
+		// the type parameters of the generic type the expression is declared in
+		typeParamsInScope := typeParamNames(typeParamsOf(yamlData, declaringTypeOf(keyName, parentItemName)))
+
+		walkTypeInstantiations(typeExpr, func(name string, typeArgs []ast.Expr) {
+			_, isDeclared := declarationOf(yamlData, name)
+			// undeclared types are reported as ErrTypeNotFound, instantiated type parameters are left to the type check
+			if (!isDeclared && !isBasicType(name)) || containsString(typeParamsInScope, name) {
+				return
+			}
+
+			// type arguments as written in the value string
+			var typeArgStrings []string
+			for _, typeArg := range typeArgs {
+				typeArgStrings = append(typeArgStrings, exprSource(valueString, typeArg))
+			}
+
+			typeParams := typeParamsOf(yamlData, name)
+			if len(typeArgs) != len(typeParams) {
+				errs = append(errs, newValidationErrorTypeArgumentCount(name, typeArgStrings, typeParamStrings(typeParams), valueString, keyName, parentItemName))
+				return
+			}
+
+			for i, typeArg := range typeArgs {
+				// type arguments referring to undeclared types or type parameters cannot be checked here
+				if len(findUndefinedTypesIn(extractTypes(typeArgStrings[i]), definedTypes)) > 0 {
+					continue
+				}
+				// neither can constraints referring to other type parameters ("S ~[]T")
+				if containsAnyString(extractConstraintTypes(typeParams[i].constraint), typeParamNames(typeParams)) {
+					continue
+				}
+				if !satisfiesConstraint(typeArg, typeParams[i].constraint, yamlData) {
+					errs = append(errs, newValidationErrorUnsatisfiedConstraint(name, typeArgStrings[i], typeParams[i].String(), valueString, keyName, parentItemName))
+				}
+			}
+		})
+	})
+
+	return
+}
+
+// the root level type a key is declared in; ("items", "list.node") => "list", ("list", "root") => "list"
+func declaringTypeOf(keyName, parentItemName string) string {
+	if parentItemName == "root" {
+		return keyName
+	}
+	return strings.Split(parentItemName, ".")[0]
+}
+
+func typeParamStrings(typeParams []typeParam) (typeParamStrings []string) {
+	for _, param := range typeParams {
+		typeParamStrings = append(typeParamStrings, param.String())
+	}
+	return
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyString(strs, candidates []string) bool {
+	for _, candidate := range candidates {
+		if containsString(strs, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// decides whether the type argument satisfies the constraint; "comparable" and unions of basic and declared
+// types ("~int | ~float64", "userID | string") are checked, constraints involving interfaces or
+// other type literals ("any", "stringer", "~[]byte") are assumed to be satisfied and left to the type check
+func satisfiesConstraint(typeArg, constraint ast.Expr, yamlData map[interface{}]interface{}) bool {
+	if ident, ok := constraint.(*ast.Ident); ok && ident.Name == "comparable" {
+		return isComparable(typeArg, yamlData)
+	}
+
+	terms := constraintTermsOf(constraint)
+	for _, term := range terms {
+		if !isCheckableConstraintTerm(term, yamlData) {
+			return true
+		}
+	}
+
+	for _, term := range terms {
+		// "~int" is satisfied by all types whose underlying type is int, "int" only by int itself
+		if approximation, ok := term.(*ast.UnaryExpr); ok {
+			if underlyingTypeString(typeArg, yamlData) == underlyingTypeString(approximation.X, yamlData) {
+				return true
+			}
+			continue
+		}
+		if identicalTypeString(typeArg, yamlData) == identicalTypeString(term, yamlData) {
+			return true
+		}
+	}
+	return false
+}
+
+// terms naming basic or declared types which are no interfaces
+func isCheckableConstraintTerm(term ast.Expr, yamlData map[interface{}]interface{}) bool {
+	if approximation, ok := term.(*ast.UnaryExpr); ok {
+		term = approximation.X
+	}
+	ident, ok := term.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if isBasicType(ident.Name) {
+		return ident.Name != "any" && ident.Name != "error"
+	}
+	value, ok := declarationOf(yamlData, ident.Name)
+	return ok && !isInterface(value) && !isAlias(value)
+}
+
+// follows aliases to the type they denote; "legacyID" => "userID" with "legacyID: = userID"
+func identicalTypeString(typeExpr ast.Expr, yamlData map[interface{}]interface{}) string {
+	return resolveTypeString(typeExpr, yamlData, false)
+}
+
+// follows named types and aliases to their underlying type; "userID" => "int" with "userID: int"
+func underlyingTypeString(typeExpr ast.Expr, yamlData map[interface{}]interface{}) string {
+	return resolveTypeString(typeExpr, yamlData, true)
+}
+
+func resolveTypeString(typeExpr ast.Expr, yamlData map[interface{}]interface{}, followNamedTypes bool) string {
+	// every declaration is followed at most once so cyclic declarations end
+	visited := make(map[string]bool)
+	for {
+		ident, ok := typeExpr.(*ast.Ident)
+		if !ok || visited[ident.Name] {
+			break
+		}
+		visited[ident.Name] = true
+
+		value, _ := declarationOf(yamlData, ident.Name)
+		if isEnum(value) && followNamedTypes {
+			return "int"
+		}
+		if !isString(value) || (!isAlias(value) && !followNamedTypes) {
+			break
+		}
+		nextTypeExpr, err := parseTypeExpr(fmt.Sprintf("%v", value))
+		if err != nil {
+			break
+		}
+		typeExpr = nextTypeExpr
+	}
+	return types.ExprString(typeExpr)
+}
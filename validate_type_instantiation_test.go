@@ -0,0 +1,114 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYamlDataTypeInstantiation(t *testing.T) {
+	t.Run("should not fail on valid instantiations", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"list", "[T any]"}:               map[interface{}]interface{}{"items": "[]T"},
+			genericTypeName{"set", "[T comparable]"}:         "map[T]bool",
+			genericTypeName{"number", "[T ~int | ~float64]"}: "[]T",
+			genericTypeName{"pair", "[K comparable, V any]"}: map[interface{}]interface{}{"key": "K", "value": "V"},
+			genericTypeName{"tree", "[T any]"}:               map[interface{}]interface{}{"children": "[]tree[T]", "values": "list[T]"},
+			"userID":                                         "int",
+			"legacyID":                                       aliasDeclaration("userID"),
+			"score":                                          "float64",
+			"user": map[interface{}]interface{}{
+				"friends": "list[user]",
+				"ids":     "set[userID]",
+				"scores":  "number[score]",
+				"legacy":  "number[legacyID]",
+				"index":   "map[string]pair[userID, *user]",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on the wrong number of type arguments", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"list", "[T any]"}:               "[]T",
+			genericTypeName{"pair", "[K comparable, V any]"}: map[interface{}]interface{}{"key": "K", "value": "V"},
+			"userID": "int",
+			"user": map[interface{}]interface{}{
+				"friends": "list",
+				"index":   "pair[string]",
+				"ids":     "[]userID[int]",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorTypeArgumentCount("list", nil, []string{"T any"}, "list", "friends", "user"),
+			newValidationErrorTypeArgumentCount("pair", []string{"string"}, []string{"K comparable", "V any"}, "pair[string]", "index", "user"),
+			newValidationErrorTypeArgumentCount("userID", []string{"int"}, nil, "[]userID[int]", "ids", "user"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on type arguments not satisfying constraints", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"set", "[T comparable]"}:         "map[T]bool",
+			genericTypeName{"number", "[T ~int | ~float64]"}: "[]T",
+			genericTypeName{"exact", "[T int | string]"}:     "[]T",
+			"userID": "int",
+			"user": map[interface{}]interface{}{
+				"tags": "[]string",
+			},
+			"foo": map[interface{}]interface{}{
+				"a": "set[[]int]",
+				"b": "set[user]",
+				"c": "number[string]",
+				"d": "number[int8]",
+				"e": "exact[userID]",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorUnsatisfiedConstraint("set", "[]int", "T comparable", "set[[]int]", "a", "foo"),
+			newValidationErrorUnsatisfiedConstraint("set", "user", "T comparable", "set[user]", "b", "foo"),
+			newValidationErrorUnsatisfiedConstraint("number", "string", "T ~int | ~float64", "number[string]", "c", "foo"),
+			newValidationErrorUnsatisfiedConstraint("number", "int8", "T ~int | ~float64", "number[int8]", "d", "foo"),
+			newValidationErrorUnsatisfiedConstraint("exact", "userID", "T int | string", "exact[userID]", "e", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should leave type parameters and undeclared types to other validations", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"set", "[T comparable]"}: "map[T]bool",
+			genericTypeName{"wrapper", "[T any]"}:    map[interface{}]interface{}{"values": "set[T]", "other": "T[int]"},
+			"foo": map[interface{}]interface{}{
+				"a": "set[[]usr]",
+				"b": "usr[int]",
+			},
+		}
+
+		actualErrors := validateTypeInstantiations(data)
+		expectedErrors := []error{}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
@@ -2,38 +2,72 @@ package yamltostruct
 
 import (
 	"fmt"
-	"regexp"
+	"go/ast"
+	"sort"
+	"unicode/utf8"
 )
 
-// returns errors if types are used which are not declared in the YAML file
-// order of declaration is irrelevant
+// returns errors if types are used which are not declared in the YAML file, either as
+// types or as types of external packages; order of declaration is irrelevant
 func validateTypeNotFound(yamlData map[interface{}]interface{}) (errs []error) {
 
 	var definedTypes []string
 
-	for key := range yamlData {
-		keyName := fmt.Sprintf("%v", key)
+	rangeTypeDeclarations(yamlData, func(keyName string, _ interface{}) {
 		definedTypes = append(definedTypes, keyName)
-	}
+	})
+	// types of external packages are referred to by qualified identifiers ("time.Time")
+	definedTypes = append(definedTypes, externalTypeNames(yamlData)...)
+
+	rangeTypeDeclarations(yamlData, func(keyName string, value interface{}) {
+		// the type parameters of generic types can be used within the type, including the constraints
+		typeParams := typeParamsOf(yamlData, keyName)
+		typesInScope := append(typeParamNames(typeParams), definedTypes...)
 
-	for key, value := range yamlData {
-		keyName := fmt.Sprintf("%v", key)
+		for _, param := range typeParams {
+			// "comparable" can only be used as constraint
+			for _, undefinedType := range findUndefinedTypesIn(extractConstraintTypes(param.constraint), append(typesInScope, "comparable")) {
+				suggestions := suggestTypeNames(undefinedType, typesInScope)
+				errs = append(errs, newValidationErrorTypeNotFound(undefinedType, param.name, keyName, suggestions...))
+			}
+		}
 
 		if isString(value) {
 			valueString := fmt.Sprintf("%v", value)
 			extractedTypes := extractTypes(valueString)
-			undefinedTypes := findUndefinedTypesIn(extractedTypes, definedTypes)
+			undefinedTypes := findUndefinedTypesIn(extractedTypes, typesInScope)
 			for _, undefinedType := range undefinedTypes {
-				errs = append(errs, newValidationErrorTypeNotFound(undefinedType, "root"))
+				suggestions := suggestTypeNames(undefinedType, typesInScope)
+				errs = append(errs, newValidationErrorTypeNotFound(undefinedType, keyName, "root", suggestions...))
 			}
 		}
 
 		if isMap(value) {
 			mapValue := value.(map[interface{}]interface{})
-			objectValidationErrs := validateTypeNotFoundObject(mapValue, keyName, definedTypes)
+			objectValidationErrs := validateTypeNotFoundObject(mapValue, keyName, typesInScope)
 			errs = append(errs, objectValidationErrs...)
 		}
-	}
+
+		// methods and embedded interfaces are declared just like fields and embedded fields
+		if isInterface(value) {
+			interfaceData := map[interface{}]interface{}(value.(interfaceDeclaration))
+			interfaceValidationErrs := validateTypeNotFoundObject(interfaceData, keyName, typesInScope)
+			errs = append(errs, interfaceValidationErrs...)
+		}
+	})
+
+	rangeValueSections(yamlData, func(sectionKey string, section valueSection) {
+		rangeInAlphabeticalOrder(section, func(keyName string, value interface{}) {
+			typeString, _, ok := valueDeclarationOf(sectionKey, value)
+			if !ok || typeString == "" {
+				return
+			}
+			for _, undefinedType := range findUndefinedTypesIn(extractTypes(typeString), definedTypes) {
+				suggestions := suggestTypeNames(undefinedType, definedTypes)
+				errs = append(errs, newValidationErrorTypeNotFound(undefinedType, keyName, sectionKey, suggestions...))
+			}
+		})
+	})
 
 	return
 }
@@ -44,31 +78,63 @@ func validateTypeNotFoundObject(
 	definedTypes []string,
 ) (errs []error) {
 
-	for _, value := range yamlObjectData {
-		if !isString(value) || isEmptyString(value) {
-			continue
+	rangeInAlphabeticalOrder(yamlObjectData, func(keyName string, value interface{}) {
+		if isMap(value) {
+			mapValue := value.(map[interface{}]interface{})
+			objectValidationErrs := validateTypeNotFoundObject(mapValue, declarationPathOf(keyName, objectName), definedTypes)
+			errs = append(errs, objectValidationErrs...)
+			return
 		}
+
 		valueString := fmt.Sprintf("%v", value)
+		// the key of an embedded field is the type it embeds
+		if isEmbeddedField(value) {
+			valueString = keyName
+		} else if !isString(value) || isEmptyString(value) {
+			return
+		}
+
 		extractedTypes := extractTypes(valueString)
 		undefinedTypes := findUndefinedTypesIn(extractedTypes, definedTypes)
 		for _, undefinedType := range undefinedTypes {
-			errs = append(errs, newValidationErrorTypeNotFound(undefinedType, objectName))
+			suggestions := suggestTypeNames(undefinedType, definedTypes)
+			errs = append(errs, newValidationErrorTypeNotFound(undefinedType, keyName, objectName, suggestions...))
 		}
-	}
+	})
 
 	return
 }
 
-// extracts all types which are defined in a type definition
+// extracts all types which are referenced in a type definition; constants used as array lengths are left out
 // map[string]int => []string{"string", "int"}
+// [n]user_id => []string{"user_id"}
+// pair[string, user] => []string{"pair", "string", "user"}
 func extractTypes(typeDefinitionString string) (extractedTypes []string) {
-	re := regexp.MustCompile(`[A-Za-z]+[0-9]*`)
-	matches := re.FindAllString(typeDefinitionString, -1)
-	for _, match := range matches {
-		if match == "map" || match == "" {
-			continue
+	typeExpr, err := parseTypeExpr(typeDefinitionString)
+	if err != nil {
+		return
+	}
+
+	walkTypeReferences(typeExpr, func(name string, isArrayLength bool) {
+		if !isArrayLength {
+			extractedTypes = append(extractedTypes, name)
+		}
+	})
+	return
+}
+
+// extracts all types which are referenced in the constraint of a type parameter
+// ~int | stringer => []string{"int", "stringer"}
+func extractConstraintTypes(constraint ast.Expr) (extractedTypes []string) {
+	for _, term := range constraintTermsOf(constraint) {
+		if approximation, ok := term.(*ast.UnaryExpr); ok {
+			term = approximation.X
 		}
-		extractedTypes = append(extractedTypes, match)
+		walkTypeReferences(term, func(name string, isArrayLength bool) {
+			if !isArrayLength {
+				extractedTypes = append(extractedTypes, name)
+			}
+		})
 	}
 	return
 }
@@ -89,3 +155,96 @@ func findUndefinedTypesIn(usedTypes, definedTypes []string) (undefinedTypes []st
 	}
 	return
 }
+
+const maxTypeNameSuggestions = 3
+
+// returns the defined or basic types closest to the undefined type, ranked by edit distance
+// "strng" => []string{"string"}
+func suggestTypeNames(undefinedType string, definedTypes []string) []string {
+	return suggestNames(undefinedType, append(append([]string{}, definedTypes...), golangBasicTypes...))
+}
+
+// returns the known names closest to the undefined name, ranked by edit distance
+func suggestNames(undefinedName string, knownNames []string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	// a typo usually affects about every third character at most
+	maxDistance := utf8.RuneCountInString(undefinedName) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	var candidates []candidate
+	seen := make(map[string]bool)
+	for _, knownName := range knownNames {
+		if seen[knownName] {
+			continue
+		}
+		seen[knownName] = true
+		distance := editDistance(undefinedName, knownName)
+		// replacing every character of a short name ("c" => "a") is no typo
+		if distance <= maxDistance && distance < utf8.RuneCountInString(undefinedName) {
+			candidates = append(candidates, candidate{knownName, distance})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < maxTypeNameSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// levenshtein distance between a and b where swapping two adjacent
+// characters counts as a single edit ("itn" => "int" is 1)
+func editDistance(a, b string) int {
+	runesA, runesB := []rune(a), []rune(b)
+
+	distances := make([][]int, len(runesA)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(runesB)+1)
+		distances[i][0] = i
+	}
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+
+	for i := 1; i <= len(runesA); i++ {
+		for j := 1; j <= len(runesB); j++ {
+			substitutionCost := 1
+			if runesA[i-1] == runesB[j-1] {
+				substitutionCost = 0
+			}
+			distances[i][j] = minInt(
+				distances[i-1][j]+1,
+				distances[i][j-1]+1,
+				distances[i-1][j-1]+substitutionCost,
+			)
+			if i > 1 && j > 1 && runesA[i-1] == runesB[j-2] && runesA[i-2] == runesB[j-1] {
+				distances[i][j] = minInt(distances[i][j], distances[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return distances[len(runesA)][len(runesB)]
+}
+
+func minInt(first int, rest ...int) int {
+	min := first
+	for _, i := range rest {
+		if i < min {
+			min = i
+		}
+	}
+	return min
+}
@@ -67,8 +67,8 @@ func TestValidateYamlDataTypeNotFound(t *testing.T) {
 
 		actualErrors := logicalValidation(data)
 		expectedErrors := []error{
-			newValidationErrorTypeNotFound("ban", "baz"),
-			newValidationErrorTypeNotFound("ban", "root"),
+			newValidationErrorTypeNotFound("ban", "bar", "baz", "baz"),
+			newValidationErrorTypeNotFound("ban", "boo", "root", "baz"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -89,8 +89,8 @@ func TestValidateYamlDataTypeNotFound(t *testing.T) {
 
 		actualErrors := logicalValidation(data)
 		expectedErrors := []error{
-			newValidationErrorTypeNotFound("schtring", "root"),
-			newValidationErrorTypeNotFound("bar", "baz"),
+			newValidationErrorTypeNotFound("schtring", "fof", "root", "string"),
+			newValidationErrorTypeNotFound("bar", "bam", "baz", "baz"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -111,8 +111,8 @@ func TestValidateYamlDataTypeNotFound(t *testing.T) {
 
 		actualErrors := logicalValidation(data)
 		expectedErrors := []error{
-			newValidationErrorTypeNotFound("schtring", "root"),
-			newValidationErrorTypeNotFound("bar", "baz"),
+			newValidationErrorTypeNotFound("schtring", "fof", "root", "string"),
+			newValidationErrorTypeNotFound("bar", "bam", "baz", "baz"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -134,11 +134,14 @@ func TestValidateYamlDataTypeNotFound(t *testing.T) {
 		}
 
 		actualErrors := logicalValidation(data)
+		// identifiers used as array lengths are constants
 		expectedErrors := []error{
-			newValidationErrorTypeNotFound("schtring", "root"),
-			newValidationErrorTypeNotFound("schtring", "root"),
-			newValidationErrorTypeNotFound("bar", "baz"),
-			newValidationErrorTypeNotFound("bar", "baz"),
+			newValidationErrorTypeNotFound("schtring", "fof", "root", "string"),
+			newValidationErrorConstantNotFound("int", "fof", "root"),
+			newValidationErrorConstantNotFound("schtring", "boo", "root"),
+			newValidationErrorTypeNotFound("bar", "bam", "baz", "baz"),
+			newValidationErrorConstantNotFound("int", "bam", "baz"),
+			newValidationErrorConstantNotFound("bar", "bal", "baz"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -154,9 +157,9 @@ func TestValidateYamlDataTypeNotFound(t *testing.T) {
 
 		actualErrors := logicalValidation(data)
 		expectedErrors := []error{
-			newValidationErrorTypeNotFound("bar", "root"),
-			newValidationErrorTypeNotFound("ban", "root"),
-			newValidationErrorTypeNotFound("baz", "root"),
+			newValidationErrorTypeNotFound("bar", "foo", "root"),
+			newValidationErrorTypeNotFound("ban", "foo", "root"),
+			newValidationErrorTypeNotFound("baz", "foo", "root"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -165,6 +168,24 @@ func TestValidateYamlDataTypeNotFound(t *testing.T) {
 		assert.Empty(t, redundantErrors)
 	})
 
+	t.Run("should not split identifiers containing underscores or digits", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"user_id": "string",
+			"a1b":     "int",
+			"baz": map[interface{}]interface{}{
+				"ban": "map[user_id]a1b",
+				"bam": "[]a1c",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorTypeNotFound("a1c", "bam", "baz", "a1b"),
+		}
+
+		assert.Equal(t, expectedErrors, actualErrors)
+	})
+
 	t.Run("should not fail when type is used before declared", func(t *testing.T) {
 		data := map[interface{}]interface{}{
 			"fof": "foo",
@@ -185,6 +206,156 @@ func TestValidateYamlDataTypeNotFound(t *testing.T) {
 		assert.Empty(t, redundantErrors)
 	})
 
+	t.Run("should fail on usage of unknown types in nested objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "string",
+			"bar": map[interface{}]interface{}{
+				"baz": map[interface{}]interface{}{
+					"ban": "foo",
+					"bam": "[]fooo",
+				},
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorTypeNotFound("fooo", "bam", "bar.baz", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on embedding unknown types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"audit": map[interface{}]interface{}{
+				"createdAt": "int64",
+			},
+			"user": map[interface{}]interface{}{
+				"audit":  nil,
+				"*audi":  nil,
+				"string": nil,
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorTypeNotFound("audi", "*audi", "user", "audit"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should fail on usage of unknown types in interfaces", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"user": map[interface{}]interface{}{
+				"name": "string",
+			},
+			"reader": interfaceDeclaration{
+				"Read": "func(p []byte) (int, error)",
+			},
+			"store": interfaceDeclaration{
+				"reader":    nil,
+				"writer":    nil,
+				"Find":      "func(id string) (*usr, error)",
+				"FindAll":   "func() []user",
+				"Broadcast": "func(c chan<- user) error",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorTypeNotFound("usr", "Find", "store", "user"),
+			newValidationErrorTypeNotFound("writer", "writer", "store"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should resolve types of func and chan types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "string",
+			"bar": "func(a, b foo, c ...fooo) (x, y foo, err error)",
+			"baz": map[interface{}]interface{}{
+				"ban": "chan foo",
+				"bam": "chan<- []fo",
+				"bal": "<-chan func(map[foo]barr)",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorTypeNotFound("fooo", "bar", "root", "foo"),
+			newValidationErrorTypeNotFound("fo", "bam", "baz", "foo"),
+			newValidationErrorTypeNotFound("barr", "bal", "baz", "bar"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should resolve type parameters within their generic type", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			genericTypeName{"list", "[T any]"}: map[interface{}]interface{}{
+				"items": "[]T",
+				"next":  "*list[U]",
+			},
+			genericTypeName{"slice", "[S ~[]E, E ordered]"}: "[]S",
+			genericTypeName{"set", "[T comparable]"}:        "map[T]bool",
+			"user": map[interface{}]interface{}{
+				"friends": "list[user]",
+				"tags":    "set[T]",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorTypeNotFound("U", "next", "list"),
+			newValidationErrorTypeNotFound("ordered", "E", "slice"),
+			newValidationErrorTypeNotFound("T", "tags", "user"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should resolve qualified identifiers of declared external types", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"import": importSection{
+				"time": map[interface{}]interface{}{
+					"types": map[interface{}]interface{}{"Time": "comparable", "Duration": "comparable"},
+				},
+			},
+			"user": map[interface{}]interface{}{
+				"created": "time.Time",
+				"timeout": "time.Duraton",
+				"id":      "uuid.UUID",
+			},
+		}
+
+		actualErrors := logicalValidation(data)
+		expectedErrors := []error{
+			newValidationErrorTypeNotFound("time.Duraton", "timeout", "user", "time.Duration"),
+			newValidationErrorTypeNotFound("uuid.UUID", "id", "user"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
 }
 
 func TestExtractTypes(t *testing.T) {
@@ -230,6 +401,86 @@ func TestExtractTypes(t *testing.T) {
 	})
 }
 
+func TestExtractTypesFromAST(t *testing.T) {
+	t.Run("should extract identifiers with underscores, digits and unicode", func(t *testing.T) {
+		assert.Equal(t, []string{"user_id"}, extractTypes("user_id"))
+		assert.Equal(t, []string{"a1b"}, extractTypes("[]a1b"))
+		assert.Equal(t, []string{"straße", "größe"}, extractTypes("map[straße]größe"))
+	})
+	t.Run("should extract qualified identifiers as a whole", func(t *testing.T) {
+		assert.Equal(t, []string{"time.Time", "string"}, extractTypes("map[time.Time]string"))
+	})
+	t.Run("should leave out constants used as array lengths", func(t *testing.T) {
+		assert.Equal(t, []string{"foo"}, extractTypes("[size]foo"))
+		assert.Equal(t, []string{"foo"}, extractTypes("[2*size]foo"))
+	})
+	t.Run("should skip field, parameter and method names", func(t *testing.T) {
+		assert.Equal(t, []string{"foo", "bar"}, extractTypes("struct{ a foo; b bar }"))
+		assert.Equal(t, []string{"foo", "bar"}, extractTypes("func(a foo) (b bar)"))
+		assert.Equal(t, []string{"foo", "bar"}, extractTypes("interface{ get(foo) bar }"))
+	})
+	t.Run("should not extract anything from invalid type definitions", func(t *testing.T) {
+		assert.Empty(t, extractTypes("[]in[t32"))
+	})
+}
+
+func TestSuggestTypeNames(t *testing.T) {
+	t.Run("should suggest closest defined and basic types", func(t *testing.T) {
+		definedTypes := []string{"person", "address", "persons", "int6"}
+
+		assert.Equal(t, []string{"person"}, suggestTypeNames("persn", definedTypes))
+		assert.Equal(t, []string{"person", "persons"}, suggestTypeNames("persno", definedTypes))
+		assert.Equal(t, []string{"string"}, suggestTypeNames("strng", definedTypes))
+		assert.Equal(t, []string{"int"}, suggestTypeNames("itn", definedTypes))
+		assert.Equal(t, []string{"int16", "int32", "int6"}, suggestTypeNames("int36", definedTypes))
+		assert.Empty(t, suggestTypeNames("vehicle", definedTypes))
+	})
+
+	t.Run("should not suggest unrelated types for short names", func(t *testing.T) {
+		definedTypes := []string{"a", "b", "u", "ab"}
+
+		assert.Empty(t, suggestTypeNames("c", definedTypes))
+		assert.Empty(t, suggestTypeNames("X", definedTypes))
+		assert.Equal(t, []string{"a", "ab", "b"}, suggestTypeNames("ba", definedTypes))
+	})
+
+	t.Run("should measure the length of names in characters", func(t *testing.T) {
+		definedTypes := []string{"gräfe", "grüße"}
+
+		assert.Equal(t, []string{"grüße"}, suggestTypeNames("größe", definedTypes))
+	})
+
+	t.Run("should include suggestions in error message", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"person": map[interface{}]interface{}{
+				"name": "strng",
+			},
+			"team": "[]persn",
+		}
+
+		actualErrors := logicalValidation(data)
+
+		assert.Equal(t, []error{
+			newValidationErrorTypeNotFound("strng", "name", "person", "string"),
+			newValidationErrorTypeNotFound("persn", "team", "root", "person"),
+		}, actualErrors)
+		assert.Equal(t,
+			"ErrTypeNotFound: type with name \"strng\" in \"person\" was not found, did you mean \"string\"?",
+			actualErrors[0].Error(),
+		)
+	})
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Run("should calculate levenshtein distance", func(t *testing.T) {
+		assert.Equal(t, 0, editDistance("foo", "foo"))
+		assert.Equal(t, 1, editDistance("strng", "string"))
+		assert.Equal(t, 1, editDistance("itn", "int"))
+		assert.Equal(t, 3, editDistance("", "foo"))
+		assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	})
+}
+
 func TestFindUndefinedTypesIn(t *testing.T) {
 	t.Run("should find all undefined types", func(t *testing.T) {
 		definedTypesInput := []string{"foo", "bar"}
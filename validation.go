@@ -1,11 +1,15 @@
 package yamltostruct
 
 import (
+	"errors"
 	"fmt"
+	"go/token"
 	"reflect"
+	"sort"
+	"strings"
 )
 
-var golangBasicTypes = []string{"string", "bool", "int8", "uint8", "byte", "int16", "uint16", "int32", "rune", "uint32", "int64", "uint64", "int", "uint", "uintptr", "float32", "float64", "complex64", "complex128"}
+var golangBasicTypes = []string{"string", "bool", "int8", "uint8", "byte", "int16", "uint16", "int32", "rune", "uint32", "int64", "uint64", "int", "uint", "uintptr", "float32", "float64", "complex64", "complex128", "error", "any"}
 
 const mockPackageName string = "foobar"
 
@@ -25,18 +29,44 @@ func isSlice(unknown interface{}) bool {
 	return false
 }
 
+// objects are declared as map[interface{}]interface{}; interface declarations are not objects
 func isMap(unknown interface{}) bool {
-	v := reflect.ValueOf(unknown)
-	if v.Kind() == reflect.Map {
-		return true
-	}
-	return false
+	_, ok := unknown.(map[interface{}]interface{})
+	return ok
+}
+
+// objects tagged with "!interface" in the YAML document declare interface types;
+// their keys are method names with func types as values, keys without value embed other interfaces
+type interfaceDeclaration map[interface{}]interface{}
+
+func isInterface(unknown interface{}) bool {
+	_, ok := unknown.(interfaceDeclaration)
+	return ok
+}
+
+// named types whose value starts with "=" declare aliases ("legacyUser: = user");
+// the aliased type is kept as string, so aliases are validated just like named types
+type aliasDeclaration string
+
+func isAlias(unknown interface{}) bool {
+	_, ok := unknown.(aliasDeclaration)
+	return ok
 }
 
 func isNil(unknown interface{}) bool {
 	return unknown == nil
 }
 
+// objects embed types by declaring them as keys without value ("audit:" or "*audit:")
+func isEmbeddedField(value interface{}) bool {
+	return isNil(value)
+}
+
+// the type an embedded field refers to; "*audit" => "audit"
+func embeddedTypeName(keyName string) string {
+	return strings.TrimPrefix(keyName, "*")
+}
+
 func isEmptyString(unknown interface{}) bool {
 	if !isString(unknown) {
 		return false
@@ -58,9 +88,15 @@ func syntacticalValidation(yamlData map[interface{}]interface{}) (errs []error)
 	illegalTypeNameErrs := validateIllegalTypeName(yamlData)
 	errs = append(errs, illegalTypeNameErrs...)
 
+	shadowedPredeclaredErrs := validateShadowedPredeclared(yamlData)
+	errs = append(errs, shadowedPredeclaredErrs...)
+
 	invalidValueStringErrs := validateInvalidValueString(yamlData)
 	errs = append(errs, invalidValueStringErrs...)
 
+	enumNameConflictErrs := validateEnumNameConflicts(yamlData)
+	errs = append(errs, enumNameConflictErrs...)
+
 	return
 }
 
@@ -75,6 +111,18 @@ func logicalValidation(yamlData map[interface{}]interface{}) (errs []error) {
 	invalidMapKeyErrs := validateIllegalMapKeys(yamlData)
 	errs = append(errs, invalidMapKeyErrs...)
 
+	promotedFieldConflictErrs := validatePromotedFieldConflicts(yamlData)
+	errs = append(errs, promotedFieldConflictErrs...)
+
+	duplicateEnumValueErrs := validateDuplicateEnumValues(yamlData)
+	errs = append(errs, duplicateEnumValueErrs...)
+
+	arrayLengthConstantErrs := validateArrayLengthConstants(yamlData)
+	errs = append(errs, arrayLengthConstantErrs...)
+
+	typeInstantiationErrs := validateTypeInstantiations(yamlData)
+	errs = append(errs, typeInstantiationErrs...)
+
 	return
 }
 
@@ -94,6 +142,285 @@ func validateYamlData(yamlData map[interface{}]interface{}) (errs []error) {
 
 	logicalErrs := logicalValidation(yamlData)
 	errs = append(errs, logicalErrs...)
+	if len(errs) != 0 {
+		return
+	}
+
+	typeCheckErrs := validateTypeCheck(yamlData)
+	errs = append(errs, typeCheckErrs...)
 
 	return
 }
+
+// runs all phases even if previous ones reported errors; declarations that
+// failed a phase are left out of the following ones so only the error-free
+// parts of the document are validated further and no follow-up errors are reported
+func validateYamlDataExhaustive(yamlData map[interface{}]interface{}) (errs []error) {
+
+	structuralErrs := structuralValidation(yamlData)
+	errs = append(errs, structuralErrs...)
+	yamlData = withoutInvalidDeclarations(yamlData, structuralErrs)
+
+	syntacticalErrs := syntacticalValidation(yamlData)
+	errs = append(errs, syntacticalErrs...)
+	yamlData = withoutInvalidDeclarations(yamlData, syntacticalErrs)
+
+	logicalErrs := logicalValidation(yamlData)
+	errs = append(errs, logicalErrs...)
+	yamlData = withoutInvalidDeclarations(yamlData, logicalErrs)
+
+	typeCheckErrs := validateTypeCheck(yamlData)
+	errs = append(errs, typeCheckErrs...)
+
+	return
+}
+
+// returns a copy of yamlData where invalid fields are removed and invalid
+// types are replaced by empty objects (or interfaces); the types are kept declared so
+// references to them do not result in an ErrTypeNotFound. Likewise invalid constants,
+// variables and enum values are replaced by placeholders, so references to them do not
+// result in an ErrConstantNotFound
+func withoutInvalidDeclarations(yamlData map[interface{}]interface{}, errs []error) map[interface{}]interface{} {
+	invalidTypes := make(map[string]bool)
+	invalidFields := make(map[string]map[string]bool)
+	// the constants generated for these enum values are declared by the conflicting declarations
+	conflictingEnumValues := make(map[string]map[string]bool)
+
+	for _, err := range errs {
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			continue
+		}
+		if validationErr.ParentObject == "root" {
+			invalidTypes[validationErr.KeyName] = true
+			continue
+		}
+		if invalidFields[validationErr.ParentObject] == nil {
+			invalidFields[validationErr.ParentObject] = make(map[string]bool)
+		}
+		invalidFields[validationErr.ParentObject][validationErr.KeyName] = true
+
+		if errors.Is(err, ErrEnumNameConflict) {
+			if conflictingEnumValues[validationErr.ParentObject] == nil {
+				conflictingEnumValues[validationErr.ParentObject] = make(map[string]bool)
+			}
+			conflictingEnumValues[validationErr.ParentObject][validationErr.KeyName] = true
+		}
+	}
+
+	validData := make(map[interface{}]interface{})
+	for key, value := range yamlData {
+		keyName := fmt.Sprintf("%v", key)
+
+		if generic, ok := key.(genericTypeName); ok {
+			key = withoutInvalidConstraints(generic, invalidFields[keyName])
+		}
+
+		// neither are external packages; invalid ones are left out, so their types are not found
+		if keyName == importSectionKey {
+			if isImportSection(value) {
+				validData[key] = withoutInvalidPackages(value.(importSection), invalidTypes, invalidFields)
+			}
+			continue
+		}
+
+		// sections are no types, so invalid ones are left out entirely
+		if isSectionKey(keyName) {
+			if !invalidTypes[keyName] && isValueSection(value) {
+				validData[key] = withPlaceholderValues(value.(valueSection), keyName, invalidFields[keyName])
+			}
+			continue
+		}
+
+		if invalidTypes[keyName] {
+			if isInterface(value) {
+				validData[key] = make(interfaceDeclaration)
+			} else {
+				validData[key] = make(map[interface{}]interface{})
+			}
+			continue
+		}
+
+		if isInterface(value) {
+			interfaceData := map[interface{}]interface{}(value.(interfaceDeclaration))
+			validData[key] = interfaceDeclaration(withoutInvalidFields(interfaceData, keyName, invalidFields))
+			continue
+		}
+
+		if isMap(value) {
+			validData[key] = withoutInvalidFields(value.(map[interface{}]interface{}), keyName, invalidFields)
+			continue
+		}
+
+		if isEnum(value) {
+			validData[key] = withoutInvalidEnumValues(value.([]interface{}), invalidFields[keyName], conflictingEnumValues[keyName])
+			continue
+		}
+
+		validData[key] = value
+	}
+
+	return validData
+}
+
+// copies the declared packages without the invalid ones
+func withoutInvalidPackages(section importSection, invalidTypes map[string]bool, invalidFields map[string]map[string]bool) importSection {
+	validSection := make(importSection)
+	if invalidTypes[importSectionKey] {
+		return validSection
+	}
+	for key, value := range section {
+		packageName := fmt.Sprintf("%v", key)
+		packagePath := declarationPathOf(packageName, importSectionKey)
+		// errors of the package are reported for its keys or its types
+		if invalidFields[importSectionKey][packageName] || len(invalidFields[packagePath]) > 0 ||
+			len(invalidFields[declarationPathOf(importTypesKey, packagePath)]) > 0 {
+			continue
+		}
+		validSection[key] = value
+	}
+	return validSection
+}
+
+// type parameters whose constraints are invalid are constrained by "any" instead,
+// so the number of type parameters stays the same for the instantiations of the type
+func withoutInvalidConstraints(generic genericTypeName, invalidTypeParams map[string]bool) genericTypeName {
+	var typeParamStrings []string
+	for _, param := range parseTypeParams(generic.typeParams) {
+		if invalidTypeParams[param.name] {
+			param.constraintString = "any"
+		}
+		typeParamStrings = append(typeParamStrings, param.String())
+	}
+	return genericTypeName{generic.name, "[" + strings.Join(typeParamStrings, ", ") + "]"}
+}
+
+// copies the declared constants and variables with the invalid ones replaced by placeholders:
+// constants by 0, which can be used wherever an integer constant is expected (e.g. as array length),
+// and variables by an empty struct, just like invalid types
+func withPlaceholderValues(section valueSection, sectionKey string, invalidNames map[string]bool) valueSection {
+	validSection := make(valueSection)
+	for key, value := range section {
+		if !invalidNames[fmt.Sprintf("%v", key)] {
+			validSection[key] = value
+			continue
+		}
+		if sectionKey == constSectionKey {
+			validSection[key] = 0
+		} else {
+			validSection[key] = "struct{}"
+		}
+	}
+	return validSection
+}
+
+// copies the enum with the invalid values assigned unused integers, so their constants stay declared;
+// values whose names conflict are left out, their constants are declared by the conflicting declarations.
+// All values are assigned explicitly, so the reassigned ones do not shift the values following them
+func withoutInvalidEnumValues(yamlEnumData []interface{}, invalidValues, conflictingValues map[string]bool) []interface{} {
+	enumValues := enumValuesOf(yamlEnumData)
+	unusedValue := 0
+	for _, enumValue := range enumValues {
+		if enumValue.value >= unusedValue {
+			unusedValue = enumValue.value + 1
+		}
+	}
+
+	validEnumData := []interface{}{}
+	for _, enumValue := range enumValues {
+		if conflictingValues[enumValue.name] {
+			continue
+		}
+		if invalidValues[enumValue.name] {
+			enumValue.value = unusedValue
+			unusedValue++
+		}
+		validEnumData = append(validEnumData, map[interface{}]interface{}{enumValue.name: enumValue.value})
+	}
+	return validEnumData
+}
+
+// copies the object and the objects nested in it without the invalid fields
+func withoutInvalidFields(
+	yamlObjectData map[interface{}]interface{},
+	objectName string,
+	invalidFields map[string]map[string]bool,
+) map[interface{}]interface{} {
+	validObjectData := make(map[interface{}]interface{})
+	for key, value := range yamlObjectData {
+		keyName := fmt.Sprintf("%v", key)
+
+		if invalidFields[objectName][keyName] {
+			continue
+		}
+
+		if isMap(value) {
+			validObjectData[key] = withoutInvalidFields(value.(map[interface{}]interface{}), declarationPathOf(keyName, objectName), invalidFields)
+			continue
+		}
+
+		validObjectData[key] = value
+	}
+	return validObjectData
+}
+
+type validationPhase int
+
+const (
+	structuralPhase validationPhase = iota
+	syntacticalPhase
+	logicalPhase
+	typeCheckPhase
+	// warnings promoted to errors are reported last
+	lintPhase
+)
+
+func validationPhaseOf(err error) validationPhase {
+	switch {
+	case errors.Is(err, ErrIllegalValue):
+		return structuralPhase
+	case errors.Is(err, ErrIllegalTypeName),
+		errors.Is(err, ErrShadowedPredeclared),
+		errors.Is(err, ErrInvalidValueString),
+		errors.Is(err, ErrNestedTypeNameConflict),
+		errors.Is(err, ErrEnumNameConflict):
+		return syntacticalPhase
+	case errors.Is(err, ErrTypeCheck):
+		return typeCheckPhase
+	case errors.Is(err, WarnUnusedType),
+		errors.Is(err, WarnFieldShadowsType),
+		errors.Is(err, WarnMixedExportedTypeNames),
+		errors.Is(err, WarnEmptyStruct),
+		errors.Is(err, WarnNamesDifferOnlyInCase):
+		return lintPhase
+	}
+	return logicalPhase
+}
+
+// orders errors by their validation phase first and by their position in the YAML source second;
+// errors without position keep the order they were reported in (alphabetically by key)
+func sortValidationErrors(errs []error) {
+	positionOf := func(err error) token.Position {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return validationErr.Pos
+		}
+		return token.Position{}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		phaseI, phaseJ := validationPhaseOf(errs[i]), validationPhaseOf(errs[j])
+		if phaseI != phaseJ {
+			return phaseI < phaseJ
+		}
+
+		posI, posJ := positionOf(errs[i]), positionOf(errs[j])
+		if posI.IsValid() != posJ.IsValid() {
+			return posI.IsValid()
+		}
+		if posI.Line != posJ.Line {
+			return posI.Line < posJ.Line
+		}
+		return posI.Column < posJ.Column
+	})
+}
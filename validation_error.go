@@ -3,61 +3,408 @@ package yamltostruct
 import (
 	"errors"
 	"fmt"
+	"go/token"
 	"strings"
 )
 
-func newValidationErrorTypeNotFound(missingTypeLiteral, parentItemName string) error {
-	return errors.New(
-		fmt.Sprintf(
+// kinds of validation errors; every *ValidationError wraps exactly one of them
+// so they can be matched with errors.Is
+var (
+	ErrIllegalValue          = errors.New("ErrIllegalValue")
+	ErrIllegalTypeName       = errors.New("ErrIllegalTypeName")
+	ErrShadowedPredeclared   = errors.New("ErrShadowedPredeclared")
+	ErrInvalidValueString    = errors.New("ErrInvalidValueString")
+	ErrTypeNotFound          = errors.New("ErrTypeNotFound")
+	ErrRecursiveTypeUsage    = errors.New("ErrRecursiveTypeUsage")
+	ErrInvalidMapKey         = errors.New("ErrInvalidMapKey")
+	ErrPromotedFieldConflict = errors.New("ErrPromotedFieldConflict")
+	ErrTypeCheck             = errors.New("ErrTypeCheck")
+	// only reported when nested objects are declared as named types (WithNamedNestedTypes)
+	ErrNestedTypeNameConflict = errors.New("ErrNestedTypeNameConflict")
+	ErrEnumNameConflict       = errors.New("ErrEnumNameConflict")
+	ErrDuplicateEnumValue     = errors.New("ErrDuplicateEnumValue")
+	ErrConstantNotFound       = errors.New("ErrConstantNotFound")
+	ErrNonIntegerConstant     = errors.New("ErrNonIntegerConstant")
+	ErrTypeArgumentCount      = errors.New("ErrTypeArgumentCount")
+	ErrUnsatisfiedConstraint  = errors.New("ErrUnsatisfiedConstraint")
+)
+
+// kinds of warnings; they are returned by UnmarshalWithWarnings as *ValidationError
+// and can be promoted to errors with WithWarningsAsErrors
+var (
+	WarnUnusedType             = errors.New("WarnUnusedType")
+	WarnFieldShadowsType       = errors.New("WarnFieldShadowsType")
+	WarnMixedExportedTypeNames = errors.New("WarnMixedExportedTypeNames")
+	WarnEmptyStruct            = errors.New("WarnEmptyStruct")
+	WarnNamesDifferOnlyInCase  = errors.New("WarnNamesDifferOnlyInCase")
+)
+
+// ValidationError describes a single problem found in the YAML document.
+// Use errors.Is with one of the Err* kinds to tell them apart,
+// or errors.As to access the details.
+type ValidationError struct {
+	Kind error
+	// the key the error refers to
+	KeyName string
+	// the object the key is declared in ("root" for top level declarations)
+	ParentObject string
+	// the value string assigned to KeyName
+	ValueString string
+	// the missing type of an ErrTypeNotFound, the instantiated type of an ErrTypeArgumentCount or ErrUnsatisfiedConstraint
+	// or the generated name of an ErrNestedTypeNameConflict or ErrEnumNameConflict
+	TypeName string
+	// the constant used as array length of an ErrConstantNotFound or ErrNonIntegerConstant
+	ConstantName string
+	// the declared types closest to TypeName (or constants closest to ConstantName), best match first
+	Suggestions []string
+	// the rejected map key of an ErrInvalidMapKey
+	MapKey string
+	// the keys forming the cycle of an ErrRecursiveTypeUsage
+	Path []string
+	// the embedded types the field of an ErrPromotedFieldConflict is promoted from
+	EmbeddedTypes []string
+	// the type arguments of an ErrTypeArgumentCount or the one of an ErrUnsatisfiedConstraint
+	TypeArguments []string
+	// the type parameters of the generic type (TypeName) the type arguments are passed to ("K comparable")
+	TypeParameters []string
+	// the name KeyName collides with (WarnNamesDifferOnlyInCase, ErrDuplicateEnumValue)
+	RelatedName string
+	// the message of the go/types checker for an ErrTypeCheck
+	Detail string
+	// where the offending key or value was declared;
+	// only set when the error was returned by Unmarshal
+	Pos token.Position
+}
+
+// the text does not include Pos, so it stays the same wherever the offending key is declared
+func (e *ValidationError) Error() string {
+	switch e.Kind {
+	case ErrTypeNotFound:
+		message := fmt.Sprintf(
 			"ErrTypeNotFound: type with name \"%s\" in \"%s\" was not found",
-			missingTypeLiteral,
-			parentItemName,
-		),
-	)
-}
-func newValidationErrorIllegalValue(keyName, parentItemName string) error {
-	return errors.New(
-		fmt.Sprintf(
+			e.TypeName,
+			e.ParentObject,
+		)
+		if len(e.Suggestions) > 0 {
+			message += fmt.Sprintf(", did you mean \"%s\"?", strings.Join(e.Suggestions, "\", \""))
+		}
+		return message
+	case ErrConstantNotFound:
+		message := fmt.Sprintf(
+			"ErrConstantNotFound: constant with name \"%s\" in \"%s\" was not found",
+			e.ConstantName,
+			e.ParentObject,
+		)
+		if len(e.Suggestions) > 0 {
+			message += fmt.Sprintf(", did you mean \"%s\"?", strings.Join(e.Suggestions, "\", \""))
+		}
+		return message
+	case ErrNonIntegerConstant:
+		return fmt.Sprintf(
+			"ErrNonIntegerConstant: constant \"%s\" used as array length of \"%s\" in \"%s\" is not an integer",
+			e.ConstantName,
+			e.KeyName,
+			e.ParentObject,
+		)
+	case ErrIllegalValue:
+		return fmt.Sprintf(
 			"ErrIllegalValue: value assigned to key \"%s\" in \"%s\" is invalid",
-			keyName,
-			parentItemName,
-		),
-	)
-}
-func newValidationErrorInvalidValueString(valueString, keyName, parentItemName string) error {
-	return errors.New(
-		fmt.Sprintf(
+			e.KeyName,
+			e.ParentObject,
+		)
+	case ErrInvalidValueString:
+		return fmt.Sprintf(
 			"ErrInvalidValueString: value \"%s\" assigned to \"%s\" in \"%s\" is invalid",
-			valueString,
-			keyName,
-			parentItemName,
-		),
-	)
-}
-func newValidationErrorIllegalTypeName(keyName, parentItemName string) error {
-	return errors.New(
-		fmt.Sprintf(
+			e.ValueString,
+			e.KeyName,
+			e.ParentObject,
+		)
+	case ErrIllegalTypeName:
+		return fmt.Sprintf(
 			"ErrIllegalTypeName: illegal type name \"%s\" in \"%s\"",
-			keyName,
-			parentItemName,
-		),
-	)
-}
-func newValidationErrorRecursiveTypeUsage(keysResultingInRecursiveness []string) error {
-	keys := strings.Join(keysResultingInRecursiveness, "->")
-	return errors.New(
-		fmt.Sprintf(
+			e.KeyName,
+			e.ParentObject,
+		)
+	case ErrShadowedPredeclared:
+		return fmt.Sprintf(
+			"ErrShadowedPredeclared: type name \"%s\" in \"%s\" shadows a predeclared identifier",
+			e.KeyName,
+			e.ParentObject,
+		)
+	case ErrRecursiveTypeUsage:
+		return fmt.Sprintf(
 			"ErrRecursiveTypeUsage: illegal recursive type detected for \"%s\"",
-			keys,
-		),
-	)
-}
-func newValidationErrorInvalidMapKey(mapKey, valueString string) error {
-	return errors.New(
-		fmt.Sprintf(
+			strings.Join(e.Path, "->"),
+		)
+	case ErrInvalidMapKey:
+		return fmt.Sprintf(
 			"ErrInvalidMapKey: \"%s\" in \"%s\" is not a valid map key",
-			mapKey,
-			valueString,
-		),
-	)
+			e.MapKey,
+			e.ValueString,
+		)
+	case ErrPromotedFieldConflict:
+		return fmt.Sprintf(
+			"ErrPromotedFieldConflict: field \"%s\" is promoted to \"%s\" by more than one embedded type (\"%s\")",
+			e.KeyName,
+			e.ParentObject,
+			strings.Join(e.EmbeddedTypes, "\", \""),
+		)
+	case ErrNestedTypeNameConflict:
+		return fmt.Sprintf(
+			"ErrNestedTypeNameConflict: type name \"%s\" generated for \"%s\" in \"%s\" is already declared",
+			e.TypeName,
+			e.KeyName,
+			e.ParentObject,
+		)
+	case ErrEnumNameConflict:
+		return fmt.Sprintf(
+			"ErrEnumNameConflict: name \"%s\" generated for \"%s\" in \"%s\" is already declared",
+			e.TypeName,
+			e.KeyName,
+			e.ParentObject,
+		)
+	case ErrDuplicateEnumValue:
+		return fmt.Sprintf(
+			"ErrDuplicateEnumValue: value %s of \"%s\" in \"%s\" is already assigned to \"%s\"",
+			e.ValueString,
+			e.KeyName,
+			e.ParentObject,
+			e.RelatedName,
+		)
+	case ErrTypeArgumentCount:
+		return fmt.Sprintf(
+			"ErrTypeArgumentCount: type \"%s\" used by \"%s\" in \"%s\" takes %d type arguments, got %d",
+			e.TypeName,
+			e.KeyName,
+			e.ParentObject,
+			len(e.TypeParameters),
+			len(e.TypeArguments),
+		)
+	case ErrUnsatisfiedConstraint:
+		return fmt.Sprintf(
+			"ErrUnsatisfiedConstraint: type argument \"%s\" of \"%s\" used by \"%s\" in \"%s\" does not satisfy \"%s\"",
+			strings.Join(e.TypeArguments, ", "),
+			e.TypeName,
+			e.KeyName,
+			e.ParentObject,
+			strings.Join(e.TypeParameters, ", "),
+		)
+	case ErrTypeCheck:
+		return fmt.Sprintf(
+			"ErrTypeCheck: declaration of \"%s\" in \"%s\" does not type-check: %s",
+			e.KeyName,
+			e.ParentObject,
+			e.Detail,
+		)
+	case WarnUnusedType:
+		return fmt.Sprintf(
+			"WarnUnusedType: type \"%s\" is declared but never used",
+			e.KeyName,
+		)
+	case WarnFieldShadowsType:
+		return fmt.Sprintf(
+			"WarnFieldShadowsType: field \"%s\" in \"%s\" has the same name as a declared type",
+			e.KeyName,
+			e.ParentObject,
+		)
+	case WarnMixedExportedTypeNames:
+		return fmt.Sprintf(
+			"WarnMixedExportedTypeNames: type name \"%s\" differs in exportedness from the other type names",
+			e.KeyName,
+		)
+	case WarnEmptyStruct:
+		return fmt.Sprintf(
+			"WarnEmptyStruct: object \"%s\" in \"%s\" has no fields",
+			e.KeyName,
+			e.ParentObject,
+		)
+	case WarnNamesDifferOnlyInCase:
+		return fmt.Sprintf(
+			"WarnNamesDifferOnlyInCase: \"%s\" and \"%s\" in \"%s\" only differ in case",
+			e.KeyName,
+			e.RelatedName,
+			e.ParentObject,
+		)
+	}
+	return fmt.Sprintf("%v: key \"%s\" in \"%s\"", e.Kind, e.KeyName, e.ParentObject)
+}
+
+// Unwrap returns the kind of the error so errors.Is(err, ErrTypeNotFound) works
+func (e *ValidationError) Unwrap() error {
+	return e.Kind
+}
+
+func newValidationErrorTypeNotFound(missingTypeLiteral, keyName, parentItemName string, suggestions ...string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrTypeNotFound,
+		TypeName:     missingTypeLiteral,
+		Suggestions:  suggestions,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorConstantNotFound(missingConstant, keyName, parentItemName string, suggestions ...string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrConstantNotFound,
+		ConstantName: missingConstant,
+		Suggestions:  suggestions,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorNonIntegerConstant(constantName, keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrNonIntegerConstant,
+		ConstantName: constantName,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorIllegalValue(keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrIllegalValue,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorInvalidValueString(valueString, keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrInvalidValueString,
+		ValueString:  valueString,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorIllegalTypeName(keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrIllegalTypeName,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorShadowedPredeclared(keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrShadowedPredeclared,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorRecursiveTypeUsage(keysResultingInRecursiveness []string) *ValidationError {
+	// the first key of the path is where the recursiveness starts ("foo" or "foo.bar")
+	keyName, parentItemName := splitDeclarationPath(keysResultingInRecursiveness[0])
+	return &ValidationError{
+		Kind:         ErrRecursiveTypeUsage,
+		Path:         keysResultingInRecursiveness,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorInvalidMapKey(mapKey, valueString, keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrInvalidMapKey,
+		MapKey:       mapKey,
+		ValueString:  valueString,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorPromotedFieldConflict(fieldName, parentItemName string, embeddedTypes []string) *ValidationError {
+	return &ValidationError{
+		Kind:          ErrPromotedFieldConflict,
+		EmbeddedTypes: embeddedTypes,
+		KeyName:       fieldName,
+		ParentObject:  parentItemName,
+	}
+}
+func newValidationErrorNestedTypeNameConflict(generatedTypeName, keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrNestedTypeNameConflict,
+		TypeName:     generatedTypeName,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorEnumNameConflict(generatedName, keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrEnumNameConflict,
+		TypeName:     generatedName,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorDuplicateEnumValue(valueString, keyName, relatedName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrDuplicateEnumValue,
+		ValueString:  valueString,
+		KeyName:      keyName,
+		RelatedName:  relatedName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationErrorTypeArgumentCount(typeName string, typeArgs, typeParams []string, valueString, keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:           ErrTypeArgumentCount,
+		TypeName:       typeName,
+		TypeArguments:  typeArgs,
+		TypeParameters: typeParams,
+		ValueString:    valueString,
+		KeyName:        keyName,
+		ParentObject:   parentItemName,
+	}
+}
+func newValidationErrorUnsatisfiedConstraint(typeName, typeArg, typeParam, valueString, keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:           ErrUnsatisfiedConstraint,
+		TypeName:       typeName,
+		TypeArguments:  []string{typeArg},
+		TypeParameters: []string{typeParam},
+		ValueString:    valueString,
+		KeyName:        keyName,
+		ParentObject:   parentItemName,
+	}
+}
+func newValidationErrorTypeCheck(detail, keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         ErrTypeCheck,
+		Detail:       detail,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+
+func newValidationWarningUnusedType(keyName string) *ValidationError {
+	return &ValidationError{
+		Kind:         WarnUnusedType,
+		KeyName:      keyName,
+		ParentObject: "root",
+	}
+}
+func newValidationWarningFieldShadowsType(keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         WarnFieldShadowsType,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationWarningMixedExportedTypeNames(keyName string) *ValidationError {
+	return &ValidationError{
+		Kind:         WarnMixedExportedTypeNames,
+		KeyName:      keyName,
+		ParentObject: "root",
+	}
+}
+func newValidationWarningEmptyStruct(keyName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         WarnEmptyStruct,
+		KeyName:      keyName,
+		ParentObject: parentItemName,
+	}
+}
+func newValidationWarningNamesDifferOnlyInCase(keyName, relatedName, parentItemName string) *ValidationError {
+	return &ValidationError{
+		Kind:         WarnNamesDifferOnlyInCase,
+		KeyName:      keyName,
+		RelatedName:  relatedName,
+		ParentObject: parentItemName,
+	}
 }
@@ -0,0 +1,48 @@
+package yamltostruct
+
+import (
+	"errors"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrorKinds(t *testing.T) {
+	t.Run("should match kind with errors.Is", func(t *testing.T) {
+		var err error = newValidationErrorTypeNotFound("foo", "bar", "root")
+
+		assert.True(t, errors.Is(err, ErrTypeNotFound))
+		assert.False(t, errors.Is(err, ErrIllegalValue))
+	})
+
+	t.Run("should expose details with errors.As", func(t *testing.T) {
+		var err error = newValidationErrorInvalidMapKey("[]foo", "map[[]foo]int", "ban", "baz")
+
+		var validationErr *ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, ErrInvalidMapKey, validationErr.Kind)
+		assert.Equal(t, "[]foo", validationErr.MapKey)
+		assert.Equal(t, "map[[]foo]int", validationErr.ValueString)
+		assert.Equal(t, "ban", validationErr.KeyName)
+		assert.Equal(t, "baz", validationErr.ParentObject)
+	})
+
+	t.Run("should keep error texts", func(t *testing.T) {
+		assert.Equal(t,
+			"ErrTypeNotFound: type with name \"foo\" in \"root\" was not found",
+			newValidationErrorTypeNotFound("foo", "bar", "root").Error(),
+		)
+		assert.Equal(t,
+			"ErrRecursiveTypeUsage: illegal recursive type detected for \"foo->bar->foo\"",
+			newValidationErrorRecursiveTypeUsage([]string{"foo", "bar", "foo"}).Error(),
+		)
+	})
+
+	t.Run("should keep error texts of errors with positions", func(t *testing.T) {
+		validationErr := newValidationErrorTypeNotFound("foo", "bar", "root")
+		validationErr.Pos = token.Position{Filename: "types.yaml", Line: 2, Column: 6}
+
+		assert.Equal(t, "ErrTypeNotFound: type with name \"foo\" in \"root\" was not found", validationErr.Error())
+	})
+}
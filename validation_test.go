@@ -2,6 +2,7 @@ package yamltostruct
 
 import (
 	"errors"
+	"go/token"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -121,6 +122,7 @@ func TestValidateYamlData(t *testing.T) {
 			"baz": map[interface{}]interface{}{
 				"ban": map[interface{}]interface{}{
 					"lan": "int32",
+					"fan": "",
 				},
 				"ran": []string{"foo"},
 				"kan": [1]string{"foo"},
@@ -132,7 +134,7 @@ func TestValidateYamlData(t *testing.T) {
 			newValidationErrorIllegalValue("bar", "root"),
 			newValidationErrorIllegalValue("baf", "root"),
 			newValidationErrorIllegalValue("bal", "root"),
-			newValidationErrorIllegalValue("ban", "baz"),
+			newValidationErrorIllegalValue("fan", "baz.ban"),
 			newValidationErrorIllegalValue("ran", "baz"),
 			newValidationErrorIllegalValue("kan", "baz"),
 		}
@@ -209,11 +211,221 @@ func TestValidateYamlData(t *testing.T) {
 		actualErrors := validateYamlData(data)
 		expectedErrors := []error{
 			newValidationErrorRecursiveTypeUsage([]string{"bam.baf", "baz.ban", "bar.foo", "bam"}),
-			newValidationErrorRecursiveTypeUsage([]string{"baz.ban", "bar.foo", "bam.baf", "baz"}),
-			newValidationErrorRecursiveTypeUsage([]string{"bar.foo", "bam.baf", "baz.ban", "bar"}),
-			newValidationErrorInvalidMapKey("[]foo", "map[[]foo]int"),
-			newValidationErrorInvalidMapKey("bunt", "map[bunt]int"),
-			newValidationErrorTypeNotFound("kan", "baz"),
+			newValidationErrorInvalidMapKey("[]foo", "map[[]foo]int", "buf", "bam"),
+			newValidationErrorInvalidMapKey("bunt", "map[bunt]int", "bul", "bam"),
+			newValidationErrorTypeNotFound("kan", "bor", "baz"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+}
+
+func TestValidateYamlDataOrder(t *testing.T) {
+	t.Run("should return errors in alphabetical order of keys", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "bam",
+			"bar": "ban",
+			"baz": map[interface{}]interface{}{
+				"fan": "kan",
+				"ban": "lan",
+			},
+		}
+
+		expectedErrors := []error{
+			newValidationErrorTypeNotFound("ban", "bar", "root", "bar", "baz"),
+			newValidationErrorTypeNotFound("lan", "ban", "baz"),
+			newValidationErrorTypeNotFound("kan", "fan", "baz"),
+			newValidationErrorTypeNotFound("bam", "foo", "root", "bar", "baz"),
+		}
+
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, expectedErrors, validateYamlData(data))
+		}
+	})
+}
+
+func TestSortValidationErrors(t *testing.T) {
+	t.Run("should sort by phase first and position second", func(t *testing.T) {
+		typeNotFoundErr := newValidationErrorTypeNotFound("bam", "foo", "root")
+		typeNotFoundErr.Pos = token.Position{Line: 1, Column: 6}
+		illegalTypeNameErr := newValidationErrorIllegalTypeName("ba$", "root")
+		illegalTypeNameErr.Pos = token.Position{Line: 4, Column: 1}
+		invalidValueStringErr := newValidationErrorInvalidValueString("[]in[t", "bar", "root")
+		invalidValueStringErr.Pos = token.Position{Line: 2, Column: 6}
+		unpositionedErr := newValidationErrorIllegalTypeName("f$o", "root")
+
+		errs := []error{typeNotFoundErr, unpositionedErr, illegalTypeNameErr, invalidValueStringErr}
+		sortValidationErrors(errs)
+
+		assert.Equal(t, []error{invalidValueStringErr, illegalTypeNameErr, unpositionedErr, typeNotFoundErr}, errs)
+	})
+}
+
+func TestValidateYamlDataExhaustive(t *testing.T) {
+	t.Run("should report errors of all phases", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo":  "",
+			"ba$r": "int",
+			"baz":  "map[int]string]",
+			"bam":  "kan",
+			"bul":  "map[bunt]int",
+			"bunt": "[]int",
+			"bal":  "[-1]int",
+			"ban": map[interface{}]interface{}{
+				"lan": []string{"foo"},
+				"fa$": "int",
+				"mal": "fan",
+			},
+		}
+
+		actualErrors := validateYamlDataExhaustive(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalValue("foo", "root"),
+			newValidationErrorIllegalValue("lan", "ban"),
+			newValidationErrorIllegalTypeName("ba$r", "root"),
+			newValidationErrorIllegalTypeName("fa$", "ban"),
+			newValidationErrorInvalidValueString("map[int]string]", "baz", "root"),
+			newValidationErrorTypeNotFound("kan", "bam", "root", "ban"),
+			newValidationErrorTypeNotFound("fan", "mal", "ban", "ban"),
+			newValidationErrorInvalidMapKey("bunt", "map[bunt]int", "bul", "root"),
+			newValidationErrorTypeCheck("invalid array length -1 (untyped int constant)", "bal", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should not report follow-up errors of invalid declarations", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": "",
+			"bar": "[]in[t",
+			"baz": map[interface{}]interface{}{
+				"ban": "foo",
+				"bam": "map[bar]int",
+			},
+		}
+
+		actualErrors := validateYamlDataExhaustive(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalValue("foo", "root"),
+			newValidationErrorInvalidValueString("[]in[t", "bar", "root"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should leave out invalid fields of nested objects", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"foo": map[interface{}]interface{}{
+				"bar": map[interface{}]interface{}{
+					"ba$": "[]in[t",
+					"ban": "kan",
+					"bam": "[-1]int",
+				},
+			},
+		}
+
+		actualErrors := validateYamlDataExhaustive(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("ba$", "foo.bar"),
+			newValidationErrorInvalidValueString("[]in[t", "ba$", "foo.bar"),
+			newValidationErrorTypeNotFound("kan", "ban", "foo.bar"),
+			newValidationErrorTypeCheck("invalid array length -1 (untyped int constant)", "bam", "foo.bar"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should leave out invalid methods of interfaces", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"ba$": interfaceDeclaration{
+				"Do": "func()",
+			},
+			"foo": interfaceDeclaration{
+				"Len": "int",
+				"Do":  "func() kan",
+				"Get": "func() [-1]int",
+			},
+			"bar": interfaceDeclaration{
+				"ba$": nil,
+			},
+		}
+
+		actualErrors := validateYamlDataExhaustive(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("ba$", "root"),
+			newValidationErrorIllegalTypeName("ba$", "bar"),
+			newValidationErrorInvalidValueString("int", "Len", "foo"),
+			newValidationErrorTypeNotFound("kan", "Do", "foo"),
+			newValidationErrorTypeCheck("invalid array length -1 (untyped int constant)", "Get", "foo"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should keep invalid constants and variables declared", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"const": valueSection{
+				"a":    "1+",
+				"b":    "a * 2",
+				"i$":   4,
+				"true": 1,
+			},
+			"var": valueSection{
+				"v": "[]in[t",
+				"w": map[interface{}]interface{}{"value": "v"},
+			},
+			"t": "[a]int",
+			"u": "[b]int",
+		}
+
+		actualErrors := validateYamlDataExhaustive(data)
+		expectedErrors := []error{
+			newValidationErrorIllegalTypeName("i$", "const"),
+			newValidationErrorShadowedPredeclared("true", "const"),
+			newValidationErrorInvalidValueString("1+", "a", "const"),
+			newValidationErrorInvalidValueString("[]in[t", "v", "var"),
+		}
+
+		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
+
+		assert.Empty(t, missingErrors)
+		assert.Empty(t, redundantErrors)
+	})
+
+	t.Run("should keep the constants of invalid enum values declared", func(t *testing.T) {
+		data := map[interface{}]interface{}{
+			"color": []interface{}{
+				map[interface{}]interface{}{"red": 1},
+				map[interface{}]interface{}{"blue": 1},
+				"green",
+			},
+			"size": []interface{}{"small", "colorRed"},
+			"const": valueSection{
+				"sizeColorRed": 3,
+			},
+			"t": "[colorBlue]int",
+			"u": "[sizeColorRed]int",
+		}
+
+		actualErrors := validateYamlDataExhaustive(data)
+		expectedErrors := []error{
+			newValidationErrorEnumNameConflict("sizeColorRed", "colorRed", "size"),
+			newValidationErrorDuplicateEnumValue("1", "blue", "red", "color"),
 		}
 
 		missingErrors, redundantErrors := matchErrors(actualErrors, expectedErrors)
@@ -0,0 +1,119 @@
+package yamltostruct
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// the reserved keys of the root level which declare constants and variables instead of types;
+// both are keywords, so they cannot be used as type names anyway
+const (
+	constSectionKey = "const"
+	varSectionKey   = "var"
+)
+
+var sectionKeys = []string{constSectionKey, varSectionKey}
+
+// the keys of a value declaration given as object ("maxPlayers: {type: int, value: 4}")
+const (
+	valueDeclarationTypeKey  = "type"
+	valueDeclarationValueKey = "value"
+)
+
+// the constants or variables declared under a reserved key; each key names a constant or variable
+type valueSection map[interface{}]interface{}
+
+func isValueSection(unknown interface{}) bool {
+	_, ok := unknown.(valueSection)
+	return ok
+}
+
+func isSectionKey(keyName string) bool {
+	for _, sectionKey := range sectionKeys {
+		if keyName == sectionKey {
+			return true
+		}
+	}
+	return false
+}
+
+// calls fn for every type declared on root level in alphabetical order, leaving out
+// the sections of constants and variables and the declaration of external packages
+func rangeTypeDeclarations(yamlData map[interface{}]interface{}, fn func(keyName string, value interface{})) {
+	rangeInAlphabeticalOrder(yamlData, func(keyName string, value interface{}) {
+		if !isReservedKey(keyName) {
+			fn(keyName, value)
+		}
+	})
+}
+
+// calls fn for the sections of constants and variables declared in yamlData, constants first
+func rangeValueSections(yamlData map[interface{}]interface{}, fn func(sectionKey string, section valueSection)) {
+	for _, sectionKey := range sectionKeys {
+		if section, ok := yamlData[sectionKey].(valueSection); ok {
+			fn(sectionKey, section)
+		}
+	}
+}
+
+// floats assigned to constants and variables are kept as written in the YAML document, as formatting
+// the decoded number would change the literal ("3.0" => "3", which is an untyped integer constant)
+type floatLiteral string
+
+func isFloatLiteral(unknown interface{}) bool {
+	_, ok := unknown.(floatLiteral)
+	return ok
+}
+
+// numbers, booleans and strings can be assigned to constants and variables
+func isScalarValue(unknown interface{}) bool {
+	switch reflect.ValueOf(unknown).Kind() {
+	case reflect.String:
+		return !isEmptyString(unknown)
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// returns the type and the value expression of a constant or variable; either may be empty.
+// Scalars are the value of constants ("maxPlayers: 4") and the type of variables ("name: string")
+// unless they are no strings ("retries: 3"); objects declare both ("timeout: {type: int64, value: 30}")
+func valueDeclarationOf(sectionKey string, value interface{}) (typeString, valueString string, ok bool) {
+	if isScalarValue(value) {
+		if sectionKey == varSectionKey && isString(value) && !isFloatLiteral(value) {
+			return fmt.Sprintf("%v", value), "", true
+		}
+		return "", fmt.Sprintf("%v", value), true
+	}
+
+	mapValue, isMapValue := value.(map[interface{}]interface{})
+	if !isMapValue {
+		return "", "", false
+	}
+	for key, item := range mapValue {
+		switch fmt.Sprintf("%v", key) {
+		case valueDeclarationTypeKey:
+			if !isString(item) || isEmptyString(item) {
+				return "", "", false
+			}
+			typeString = fmt.Sprintf("%v", item)
+		case valueDeclarationValueKey:
+			if !isScalarValue(item) {
+				return "", "", false
+			}
+			valueString = fmt.Sprintf("%v", item)
+		default:
+			return "", "", false
+		}
+	}
+
+	// constants always need a value, variables need at least a type or a value
+	if valueString == "" && (sectionKey == constSectionKey || typeString == "") {
+		return "", "", false
+	}
+	return typeString, valueString, true
+}
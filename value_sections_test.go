@@ -0,0 +1,66 @@
+package yamltostruct
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueDeclarationOf(t *testing.T) {
+	t.Run("should return type and value of constants", func(t *testing.T) {
+		for _, testCase := range []struct {
+			value                      interface{}
+			expectedType, expectedExpr string
+		}{
+			{4, "", "4"},
+			{2.5, "", "2.5"},
+			{true, "", "true"},
+			{"maxPlayers / 2", "", "maxPlayers / 2"},
+			{map[interface{}]interface{}{"type": "int64", "value": 30}, "int64", "30"},
+			{map[interface{}]interface{}{"value": `"hello"`}, "", `"hello"`},
+		} {
+			typeString, valueString, ok := valueDeclarationOf(constSectionKey, testCase.value)
+			assert.True(t, ok, testCase.value)
+			assert.Equal(t, testCase.expectedType, typeString, testCase.value)
+			assert.Equal(t, testCase.expectedExpr, valueString, testCase.value)
+		}
+	})
+
+	t.Run("should return type and value of variables", func(t *testing.T) {
+		for _, testCase := range []struct {
+			value                      interface{}
+			expectedType, expectedExpr string
+		}{
+			{"string", "string", ""},
+			{3, "", "3"},
+			{map[interface{}]interface{}{"type": "[]int"}, "[]int", ""},
+			{map[interface{}]interface{}{"type": "int64", "value": 30}, "int64", "30"},
+			{floatLiteral("3.0"), "", "3.0"},
+			{map[interface{}]interface{}{"type": "float32", "value": floatLiteral("1e6")}, "float32", "1e6"},
+		} {
+			typeString, valueString, ok := valueDeclarationOf(varSectionKey, testCase.value)
+			assert.True(t, ok, testCase.value)
+			assert.Equal(t, testCase.expectedType, typeString, testCase.value)
+			assert.Equal(t, testCase.expectedExpr, valueString, testCase.value)
+		}
+	})
+
+	t.Run("should reject invalid declarations", func(t *testing.T) {
+		for _, value := range []interface{}{
+			nil,
+			"",
+			[]interface{}{1},
+			map[interface{}]interface{}{},
+			map[interface{}]interface{}{"type": "int"},
+			map[interface{}]interface{}{"value": 1, "foo": "bar"},
+			map[interface{}]interface{}{"value": []interface{}{1}},
+			map[interface{}]interface{}{"type": 1, "value": 1},
+		} {
+			_, _, ok := valueDeclarationOf(constSectionKey, value)
+			assert.False(t, ok, value)
+		}
+
+		_, _, ok := valueDeclarationOf(varSectionKey, map[interface{}]interface{}{})
+		assert.False(t, ok)
+	})
+}